@@ -0,0 +1,72 @@
+package cron
+
+import (
+	"iter"
+	"time"
+)
+
+// Activations returns the activation times of the schedule later than from,
+// in order. The sequence ends when the schedule has no more activations,
+// i.e. Next returns the zero time or a time that isn't later.
+//
+// The sequence may be infinite, e.g. for a ConstantDelaySchedule, so it must
+// be bounded by the caller, see NextN and Between.
+//
+//	for t := range cron.Activations(schedule, time.Now()) {
+//		if ... {
+//			break
+//		}
+//	}
+func Activations(schedule Schedule, from time.Time) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		for t := from; ; {
+			next := schedule.Next(t)
+			if next.IsZero() || !next.After(t) || !yield(next) {
+				return
+			}
+			t = next
+		}
+	}
+}
+
+// NextN returns the next n activation times of the schedule later than from,
+// or fewer if the schedule has no more activations.
+func NextN(schedule Schedule, from time.Time, n int) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		if n <= 0 {
+			return
+		}
+		i := 0
+		for t := range Activations(schedule, from) {
+			if !yield(t) {
+				return
+			}
+			if i++; i == n {
+				return
+			}
+		}
+	}
+}
+
+// Between returns the activation times of the schedule later than from and
+// not later than to.
+func Between(schedule Schedule, from, to time.Time) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		for t := range Activations(schedule, from) {
+			if t.After(to) || !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// Count returns the number of activations of the schedule later than from and
+// not later than to. The activations are enumerated, so it takes time
+// proportional to their number.
+func Count(schedule Schedule, from, to time.Time) int {
+	n := 0
+	for range Between(schedule, from, to) {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,83 @@
+package cron
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActivations(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	schedule, err := ParseStandard("TZ=UTC 0 9,17 * * *")
+	require.NoError(t, err)
+
+	var got []time.Time
+	for t := range Activations(schedule, base) {
+		if got = append(got, t); len(got) == 3 {
+			break
+		}
+	}
+	assert.Equal(t, []time.Time{
+		base.Add(9 * time.Hour),
+		base.Add(17 * time.Hour),
+		base.Add(33 * time.Hour),
+	}, got)
+
+	assert.Empty(t, slices.Collect(Activations(new(ZeroSchedule), base)))
+
+	unsatisfiable, err := ParseStandard("0 0 30 2 *")
+	require.NoError(t, err)
+	assert.Empty(t, slices.Collect(Activations(unsatisfiable, base)))
+}
+
+func TestNextN(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, []time.Time{
+		base.Add(time.Hour),
+		base.Add(2 * time.Hour),
+		base.Add(3 * time.Hour),
+	}, slices.Collect(NextN(Every(time.Hour), base, 3)))
+	assert.Empty(t, slices.Collect(NextN(Every(time.Hour), base, 0)))
+	assert.Empty(t, slices.Collect(NextN(new(ZeroSchedule), base, 3)))
+
+	yearly, err := NewParser(Minute | Hour | Dom | Month | Dow | YearOptional).Parse("0 0 1 1 * 2025-2026")
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{
+		time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}, slices.Collect(NextN(yearly, base, 5)))
+}
+
+func TestBetween(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	schedule, err := ParseStandard("TZ=UTC */15 * * * *")
+	require.NoError(t, err)
+
+	assert.Equal(t, []time.Time{
+		base.Add(15 * time.Minute),
+		base.Add(30 * time.Minute),
+		base.Add(45 * time.Minute),
+		base.Add(time.Hour),
+	}, slices.Collect(Between(schedule, base, base.Add(time.Hour))))
+	assert.Empty(t, slices.Collect(Between(schedule, base, base.Add(time.Minute))))
+
+	for range Between(Every(time.Second), base, base.Add(time.Hour)) {
+		break
+	}
+}
+
+func TestCount(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	workHours, err := ParseStandard("TZ=UTC */15 9-17 * * 1-5")
+	require.NoError(t, err)
+
+	assert.Equal(t, 24, Count(Every(time.Hour), base, base.Add(24*time.Hour)))
+	assert.Equal(t, 0, Count(Every(time.Hour), base, base))
+	// Monday through Friday, 4 runs an hour from 09:00 to 17:45.
+	assert.Equal(t, 5*9*4, Count(workHours, base, base.AddDate(0, 0, 7)))
+	assert.Equal(t, 0, Count(new(ZeroSchedule), base, base.AddDate(1, 0, 0)))
+}
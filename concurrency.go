@@ -0,0 +1,103 @@
+package cron
+
+import "context"
+
+// ConcurrencyPolicy decides what happens to a job run when the maximum number
+// of concurrent jobs of a Cron has been reached, see WithMaxConcurrentJobs.
+type ConcurrencyPolicy struct {
+	kind    concurrencyKind
+	backlog int
+}
+
+type concurrencyKind int
+
+const (
+	concurrencySkip concurrencyKind = iota
+	concurrencyQueue
+	concurrencyBlock
+)
+
+// ConcurrencySkip skips the run and emits a JobSkipped event.
+func ConcurrencySkip() ConcurrencyPolicy {
+	return ConcurrencyPolicy{kind: concurrencySkip}
+}
+
+// ConcurrencyQueue queues the run until a running job returns. At most backlog
+// runs are queued, further runs are skipped and emit a JobSkipped event.
+func ConcurrencyQueue(backlog int) ConcurrencyPolicy {
+	return ConcurrencyPolicy{kind: concurrencyQueue, backlog: backlog}
+}
+
+// ConcurrencyBlock blocks the scheduling loop until a running job returns.
+// No other entry is run, added or removed in the meantime. Stopping the Cron
+// skips the run instead.
+func ConcurrencyBlock() ConcurrencyPolicy {
+	return ConcurrencyPolicy{kind: concurrencyBlock}
+}
+
+// tryAcquireSlot reserves a slot for a job run if one is available.
+func (c *Cron) tryAcquireSlot() bool {
+	select {
+	case c.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// waitSlot waits for a slot for a job run, reporting false if ctx is done or
+// stopping is closed first.
+func (c *Cron) waitSlot(ctx context.Context, stopping <-chan struct{}) bool {
+	select {
+	case c.slots <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	case <-stopping:
+		return false
+	}
+}
+
+// releaseSlot frees the slot of a job run that has returned.
+func (c *Cron) releaseSlot() {
+	if c.slots != nil {
+		<-c.slots
+	}
+}
+
+// enqueueJob counts a queued job run, reporting false if the backlog is full.
+func (c *Cron) enqueueJob() bool {
+	c.inflightMu.Lock()
+	defer c.inflightMu.Unlock()
+	if c.queued >= c.concurrency.backlog {
+		return false
+	}
+	c.queued++
+	return true
+}
+
+// dequeueJob uncounts a queued job run.
+func (c *Cron) dequeueJob() {
+	c.inflightMu.Lock()
+	defer c.inflightMu.Unlock()
+	c.queued--
+}
+
+// RunningJobs returns the number of jobs that are currently running.
+func (c *Cron) RunningJobs() int {
+	c.inflightMu.Lock()
+	defer c.inflightMu.Unlock()
+	running := 0
+	for _, n := range c.inflight {
+		running += n
+	}
+	return running
+}
+
+// QueuedJobs returns the number of job runs waiting for a running job to
+// return, see ConcurrencyQueue.
+func (c *Cron) QueuedJobs() int {
+	c.inflightMu.Lock()
+	defer c.inflightMu.Unlock()
+	return c.queued
+}
@@ -0,0 +1,170 @@
+package cron
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCron_MaxConcurrentJobs(t *testing.T) {
+	newCron := func(
+		t *testing.T, policy ConcurrencyPolicy, recorder *eventRecorder,
+	) (*Cron, EntryID, chan struct{}, chan struct{}) {
+		started, release := make(chan struct{}, 10), make(chan struct{})
+		cron := New(WithMaxConcurrentJobs(1, policy), WithEventListener(recorder.listen))
+		id, err := cron.AddFunc("@yearly", func(context.Context) error {
+			started <- struct{}{}
+			<-release
+			return nil
+		})
+		require.NoError(t, err)
+		cron.Start()
+		t.Cleanup(func() { cron.Stop() })
+		return cron, id, started, release
+	}
+
+	skipped := func(recorder *eventRecorder) []string {
+		var reasons []string
+		for _, event := range recorder.Events() {
+			if e, ok := event.(JobSkipped); ok {
+				reasons = append(reasons, e.Reason)
+			}
+		}
+		return reasons
+	}
+
+	t.Run("skip", func(t *testing.T) {
+		var recorder eventRecorder
+		cron, id, started, release := newCron(t, ConcurrencySkip(), &recorder)
+
+		require.NoError(t, cron.RunNow(id))
+		<-started
+		assert.Equal(t, 1, cron.RunningJobs())
+
+		require.NoError(t, cron.RunNow(id))
+		assert.Equal(t, []string{SkipReasonConcurrencyLimit}, skipped(&recorder))
+		assert.Equal(t, 1, cron.RunningJobs())
+		close(release)
+	})
+
+	t.Run("skip keeps prev", func(t *testing.T) {
+		var (
+			recorder eventRecorder
+			base     = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			clock    = newManualClock(base)
+			started  = make(chan struct{}, 10)
+			release  = make(chan struct{})
+		)
+		cron := New(WithClock(clock), WithLocation(time.UTC),
+			WithMaxConcurrentJobs(1, ConcurrencySkip()), WithEventListener(recorder.listen))
+		id := cron.Schedule(Every(time.Second), JobFunc(func(context.Context) error {
+			started <- struct{}{}
+			<-release
+			return nil
+		}))
+		cron.Start()
+		defer cron.Stop()
+		defer close(release)
+
+		clock.fire(base.Add(time.Second))
+		<-started
+		clock.fire(base.Add(2 * time.Second))
+		assert.Equal(t, []string{SkipReasonConcurrencyLimit}, skipped(&recorder))
+		entry := cron.Entry(id)
+		assert.Equal(t, base.Add(time.Second), entry.Prev())
+		assert.Equal(t, base.Add(3*time.Second), entry.Next())
+	})
+
+	t.Run("queue", func(t *testing.T) {
+		var recorder eventRecorder
+		cron, id, started, release := newCron(t, ConcurrencyQueue(1), &recorder)
+
+		require.NoError(t, cron.RunNow(id))
+		<-started
+		require.NoError(t, cron.RunNow(id))
+		require.NoError(t, cron.RunNow(id))
+		assert.Equal(t, 1, cron.RunningJobs())
+		assert.Equal(t, 1, cron.QueuedJobs())
+		assert.Equal(t, []string{SkipReasonQueueFull}, skipped(&recorder))
+
+		release <- struct{}{}
+		<-started
+		assert.Equal(t, 0, cron.QueuedJobs())
+		close(release)
+	})
+
+	t.Run("block", func(t *testing.T) {
+		var recorder eventRecorder
+		cron, id, started, release := newCron(t, ConcurrencyBlock(), &recorder)
+
+		require.NoError(t, cron.RunNow(id))
+		<-started
+
+		blocked := make(chan error, 1)
+		go func() { blocked <- cron.RunNow(id) }()
+		select {
+		case <-blocked:
+			t.Fatal("expected the scheduling loop to be blocked")
+		case <-time.After(10 * time.Millisecond):
+		}
+
+		release <- struct{}{}
+		require.NoError(t, <-blocked)
+		<-started
+		assert.Empty(t, skipped(&recorder))
+		close(release)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		var recorder eventRecorder
+		cron, id, started, release := newCron(t, ConcurrencyQueue(1), &recorder)
+
+		require.NoError(t, cron.RunNow(id))
+		<-started
+		require.NoError(t, cron.RunNow(id))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		assert.Error(t, cron.Shutdown(ctx))
+		close(release)
+		assert.Eventually(t, func() bool {
+			return len(skipped(&recorder)) == 1
+		}, time.Second, time.Millisecond)
+		assert.Equal(t, []string{SkipReasonCanceled}, skipped(&recorder))
+	})
+
+	t.Run("block shutdown", func(t *testing.T) {
+		var (
+			recorder eventRecorder
+			base     = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			clock    = newManualClock(base)
+			started  = make(chan struct{}, 10)
+		)
+		cron := New(WithClock(clock), WithMaxConcurrentJobs(1, ConcurrencyBlock()), WithEventListener(recorder.listen))
+		cron.Schedule(Every(time.Second), JobFunc(func(ctx context.Context) error {
+			started <- struct{}{}
+			<-ctx.Done()
+			return ctx.Err()
+		}))
+		cron.Start()
+
+		clock.fire(base.Add(time.Second))
+		<-started
+
+		// Fire without waiting for the next timer: the loop blocks for a slot.
+		timer := <-clock.timers
+		clock.mu.Lock()
+		clock.now = base.Add(2 * time.Second)
+		clock.mu.Unlock()
+		timer <- clock.now
+		time.Sleep(10 * time.Millisecond)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		require.NoError(t, cron.Shutdown(ctx))
+		assert.Equal(t, []string{SkipReasonCanceled}, skipped(&recorder))
+	})
+}
@@ -52,3 +52,36 @@ func TestConstantDelayNext(t *testing.T) {
 		}
 	}
 }
+
+func TestConstantDelaySchedule_String(t *testing.T) {
+	if s := Every(5 * time.Minute).String(); s != "@every 5m0s" {
+		t.Errorf("unexpected string %q", s)
+	}
+	sched, err := ParseStandard(Every(90 * time.Second).String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sched != Every(90*time.Second) {
+		t.Errorf("unexpected schedule %v", sched)
+	}
+}
+
+func TestConstantDelayPrev(t *testing.T) {
+	tests := []struct {
+		time     string
+		delay    time.Duration
+		expected string
+	}{
+		{"Mon Jul 9 15:00 2012", 15 * time.Minute, "Mon Jul 9 14:45 2012"},
+		{"Mon Jul 9 00:10 2012", 15 * time.Minute, "Sun Jul 8 23:55 2012"},
+		{"Mon Jul 9 15:00:00.5 2012", time.Hour, "Mon Jul 9 14:00 2012"},
+	}
+
+	for _, c := range tests {
+		actual := Every(c.delay).Prev(getTime(c.time))
+		expected := getTime(c.expected)
+		if actual != expected {
+			t.Errorf("%s, \"%s\": (expected) %v != %v (actual)", c.time, c.delay, expected, actual)
+		}
+	}
+}
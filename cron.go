@@ -2,6 +2,8 @@ package cron
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sort"
 	"sync"
 	"time"
@@ -12,19 +14,35 @@ import (
 // be inspected while running.
 type Cron struct {
 	ctx         context.Context
-	entries     []*Entry
+	jobCtx      context.Context
+	cancelJobs  context.CancelFunc
+	entries     *entryStore
 	middlewares []Middleware
 	stop        chan struct{}
+	stopping    chan struct{}
 	add         chan *Entry
 	remove      chan EntryID
+	update      chan entryUpdate
 	snapshot    chan chan []Entry
+	lookup      chan entryLookup
 	running     bool
 	logger      Logger
+	errHandler  ErrorHandler
+	misfire     MisfirePolicy
+	events      eventBus
 	runningMu   sync.Mutex
 	location    *time.Location
+	clock       Clock
 	parser      ScheduleParser
 	nextID      EntryID
+	names       map[string]EntryID
+	nameByID    map[EntryID]string
 	jobWaiter   sync.WaitGroup
+	inflightMu  sync.Mutex
+	inflight    map[EntryID]int
+	queued      int
+	slots       chan struct{}
+	concurrency ConcurrencyPolicy
 }
 
 // ScheduleParser is an interface for schedule spec parsers that return a Schedule
@@ -32,6 +50,14 @@ type ScheduleParser interface {
 	Parse(spec string) (Schedule, error)
 }
 
+// KeyedScheduleParser is a ScheduleParser that resolves parts of the specs
+// from a key, such as the hashed values of Parser, see Hash. The Cron parses
+// the specs of named entries with their name as key.
+type KeyedScheduleParser interface {
+	ScheduleParser
+	ParseWithKey(spec, key string) (Schedule, error)
+}
+
 // Schedule describes a job's duty cycle.
 type Schedule interface {
 	// Next returns the next activation time, later than the given time.
@@ -39,25 +65,28 @@ type Schedule interface {
 	Next(time.Time) time.Time
 }
 
-// byTime is a wrapper for sorting the entry array by time
-// (with zero time at the end).
-type byTime []*Entry
+// PrevSchedule is a Schedule that can also compute its previous activation
+// times, such as SpecSchedule and ConstantDelaySchedule, e.g. to tell when a
+// job was last expected to run.
+type PrevSchedule interface {
+	Schedule
 
-func (s byTime) Len() int      { return len(s) }
-func (s byTime) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
-func (s byTime) Less(i, j int) bool {
-	// Two zero times should return false.
-	// Otherwise, zero is "greater" than any other time.
-	// (To sort it at the end of the list.)
-	if s[i].next.IsZero() {
-		return false
-	}
-	if s[j].next.IsZero() {
-		return true
-	}
-	return s[i].next.Before(s[j].next)
+	// Prev returns the previous activation time, earlier than the given time.
+	Prev(time.Time) time.Time
 }
 
+// ErrEntryNotFound is returned when an operation refers to an entry that does
+// not exist in the Cron.
+var ErrEntryNotFound = errors.New("cron: entry not found")
+
+// ErrDuplicateEntryName is returned when an entry is added with the name of
+// an existing entry.
+var ErrDuplicateEntryName = errors.New("cron: duplicate entry name")
+
+// ErrorHandler handles the error returned by a job run of the given entry,
+// which is a snapshot taken when the run started.
+type ErrorHandler func(ctx context.Context, entry *Entry, err error)
+
 // New returns a new Cron job runner, modified by the given options.
 //
 // Available Settings
@@ -74,24 +103,52 @@ func (s byTime) Less(i, j int) bool {
 //	  Description: Wrap submitted jobs to customize behavior.
 //	  Default:     A chain that recovers panics and logs them to stderr.
 //
+//	Clock
+//	  Description: The source of time used to drive the schedules.
+//	  Default:     The system clock
+//
+//	Error Handler
+//	  Description: Handles the errors returned by jobs.
+//	  Default:     Logs the error and the entry id with the configured Logger.
+//
+//	Misfire Policy
+//	  Description: Decides how entries are run when activations were missed.
+//	  Default:     MisfireFireOnce, the entry is run once
+//
+//	Max Concurrent Jobs
+//	  Description: Limits the number of jobs running at the same time.
+//	  Default:     Unlimited
+//
+//	Event Listeners
+//	  Description: Observe the scheduling decisions, see Event.
+//	  Default:     None
+//
 // See "cron.With*" to modify the default behavior.
 func New(opts ...Option) *Cron {
 	c := &Cron{
 		ctx:       context.Background(),
-		entries:   nil,
+		entries:   newEntryStore(),
 		add:       make(chan *Entry),
 		stop:      make(chan struct{}),
 		snapshot:  make(chan chan []Entry),
+		lookup:    make(chan entryLookup),
 		remove:    make(chan EntryID),
+		update:    make(chan entryUpdate),
 		running:   false,
 		runningMu: sync.Mutex{},
+		inflight:  make(map[EntryID]int),
+		names:     make(map[string]EntryID),
+		nameByID:  make(map[EntryID]string),
 		logger:    DefaultLogger,
 		location:  time.Local,
+		clock:     DefaultClock,
+		misfire:   MisfireFireOnce(),
 		parser:    standardParser,
 	}
 	for _, opt := range opts {
 		opt(c)
 	}
+	c.jobCtx, c.cancelJobs = context.WithCancel(c.ctx)
 	return c
 }
 
@@ -118,21 +175,63 @@ func (c *Cron) AddJob(spec string, cmd Job, middlewares ...Middleware) (EntryID,
 	return c.Schedule(schedule, cmd, middlewares...), nil
 }
 
+// AddJobContext is like AddJob, but keeps the values of the given context,
+// e.g. the trace context of the caller, in the entry, see Entry.CallerContext.
+func (c *Cron) AddJobContext(ctx context.Context, spec string, cmd Job, middlewares ...Middleware) (EntryID, error) {
+	return c.AddEntry(spec, cmd, WithEntryCallerContext(ctx), WithEntryMiddlewares(middlewares...))
+}
+
+// AddEntry adds a Job to the Cron to be run on the given schedule, configured
+// by the given entry options such as WithEntryName and WithEntryMiddlewares.
+// The spec is parsed using the time zone of this Cron instance as the default,
+// with the name of the entry as key, see KeyedScheduleParser.
+// It returns ErrDuplicateEntryName if an entry with the same name exists.
+func (c *Cron) AddEntry(spec string, cmd Job, opts ...EntryOption) (EntryID, error) {
+	var probe Entry
+	for _, opt := range opts {
+		opt(&probe)
+	}
+	schedule, err := c.parse(spec, probe.name)
+	if err != nil {
+		return 0, err
+	}
+	return c.ScheduleEntry(schedule, cmd, opts...)
+}
+
 // Schedule adds a Job to the Cron to be run on the given schedule.
 // The job is wrapped with the configured Chain.
 func (c *Cron) Schedule(schedule Schedule, cmd Job, middlewares ...Middleware) EntryID {
+	id, _ := c.ScheduleEntry(schedule, cmd, WithEntryMiddlewares(middlewares...))
+	return id
+}
+
+// ScheduleEntry is like Schedule, but configures the entry with the given
+// entry options. It returns ErrDuplicateEntryName if an entry with the same
+// name exists.
+func (c *Cron) ScheduleEntry(schedule Schedule, cmd Job, opts ...EntryOption) (EntryID, error) {
 	c.runningMu.Lock()
 	defer c.runningMu.Unlock()
+	entry := NewEntry(c.nextID+1, schedule, cmd, opts...)
+	// The middlewares of the Cron wrap the ones of the entry.
+	if len(c.middlewares) > 0 {
+		entry.middlewares = append(c.middlewares[:len(c.middlewares):len(c.middlewares)], entry.middlewares...)
+		entry.wrap()
+	}
+	if entry.name != "" {
+		if _, ok := c.names[entry.name]; ok {
+			return 0, fmt.Errorf("%w: %s", ErrDuplicateEntryName, entry.name)
+		}
+		c.names[entry.name] = entry.id
+		c.nameByID[entry.id] = entry.name
+	}
 	c.nextID++
-	entry := NewEntry(c.nextID, schedule, cmd, WithEntryMiddlewares(
-		append(c.middlewares, middlewares...)...),
-	)
 	if !c.running {
-		c.entries = append(c.entries, entry)
+		c.entries.add(entry)
+		c.events.publish(EntryAdded{Time: c.now(), Entry: entry.snapshot()})
 	} else {
 		c.add <- entry
 	}
-	return entry.id
+	return entry.id, nil
 }
 
 // Entries returns a snapshot of the cron entries.
@@ -152,25 +251,168 @@ func (c *Cron) Location() *time.Location {
 	return c.location
 }
 
-// Entry returns a snapshot of the given entry, or nil if it couldn't be found.
+// Now returns the current time of the clock of the Cron, see WithClock, in its
+// time zone location.
+func (c *Cron) Now() time.Time {
+	return c.now()
+}
+
+// Entry returns a snapshot of the given entry, or the zero entry if it
+// couldn't be found.
 func (c *Cron) Entry(id EntryID) Entry {
-	for _, entry := range c.Entries() {
-		if id == entry.id {
-			return entry
-		}
+	c.runningMu.Lock()
+	defer c.runningMu.Unlock()
+	if c.running {
+		reply := make(chan Entry, 1)
+		c.lookup <- entryLookup{id: id, reply: reply}
+		return <-reply
 	}
-	return Entry{}
+	return c.entryByID(id)
+}
+
+// entryLookup is a request for a snapshot of an entry to the scheduling loop.
+type entryLookup struct {
+	id    EntryID
+	reply chan Entry
+}
+
+// EntryByName returns a snapshot of the entry with the given name, or the zero
+// entry if it couldn't be found.
+func (c *Cron) EntryByName(name string) Entry {
+	c.runningMu.Lock()
+	id, ok := c.names[name]
+	c.runningMu.Unlock()
+	if !ok {
+		return Entry{}
+	}
+	return c.Entry(id)
+}
+
+// RemoveByName removes the entry with the given name from being run in the
+// future. It returns ErrEntryNotFound if there is no such entry.
+func (c *Cron) RemoveByName(name string) error {
+	c.runningMu.Lock()
+	id, ok := c.names[name]
+	c.runningMu.Unlock()
+	if !ok {
+		return ErrEntryNotFound
+	}
+	c.Remove(id)
+	return nil
 }
 
 // Remove an entry from being run in the future.
 func (c *Cron) Remove(id EntryID) {
 	c.runningMu.Lock()
 	defer c.runningMu.Unlock()
+	if name, ok := c.nameByID[id]; ok {
+		delete(c.names, name)
+		delete(c.nameByID, id)
+	}
 	if c.running {
 		c.remove <- id
-	} else {
-		c.removeEntry(id)
+	} else if c.removeEntry(id) {
+		c.events.publish(EntryRemoved{Time: c.now(), EntryID: id})
+	}
+}
+
+// Pause stops the given entry from being run until it is resumed. The entry
+// keeps its id, middlewares and previous run time, and its next activation
+// time is zero while it is paused.
+func (c *Cron) Pause(id EntryID) error {
+	return c.updateEntry(id, "paused", true, func(e *Entry) {
+		e.paused = true
+	})
+}
+
+// Resume schedules a paused entry again from the current time.
+func (c *Cron) Resume(id EntryID) error {
+	return c.updateEntry(id, "resumed", true, func(e *Entry) {
+		e.paused = false
+	})
+}
+
+// Reschedule replaces the schedule of the given entry and recomputes its next
+// activation time, keeping its id, job and previous run time.
+func (c *Cron) Reschedule(id EntryID, schedule Schedule) error {
+	return c.updateEntry(id, "rescheduled", true, func(e *Entry) {
+		e.schedule = schedule
+	})
+}
+
+// RescheduleSpec is like Reschedule, but parses the schedule from the given spec
+// using the parser of this Cron instance.
+func (c *Cron) RescheduleSpec(id EntryID, spec string) error {
+	c.runningMu.Lock()
+	name := c.nameByID[id]
+	c.runningMu.Unlock()
+	schedule, err := c.parse(spec, name)
+	if err != nil {
+		return err
+	}
+	return c.Reschedule(id, schedule)
+}
+
+// ReplaceJob replaces the job of the given entry, wrapping it with the
+// middlewares of the entry. Runs that are in progress are not affected.
+func (c *Cron) ReplaceJob(id EntryID, job Job) error {
+	return c.updateEntry(id, "replaced", true, func(e *Entry) {
+		e.job = job
+		e.wrap()
+	})
+}
+
+// RunNow runs the job of the given entry immediately, through its middlewares,
+// without changing its schedule. The context passed to the job reports
+// TriggerManual, see TriggerFromContext.
+func (c *Cron) RunNow(id EntryID) error {
+	return c.updateEntry(id, "run now", false, func(e *Entry) {
+		c.startJob(e, TriggerManual, time.Time{}, 0)
+	})
+}
+
+// parse parses the spec with the given key if the parser is a
+// KeyedScheduleParser and the key is not empty.
+func (c *Cron) parse(spec, key string) (Schedule, error) {
+	if p, ok := c.parser.(KeyedScheduleParser); ok && key != "" {
+		return p.ParseWithKey(spec, key)
 	}
+	return c.parser.Parse(spec)
+}
+
+// updateEntry applies the given change to an entry, in the scheduling loop if
+// the Cron is running, in which case the next activation time of the entry is
+// recomputed afterward if reschedule is true.
+func (c *Cron) updateEntry(id EntryID, msg string, reschedule bool, apply func(e *Entry)) error {
+	c.runningMu.Lock()
+	defer c.runningMu.Unlock()
+	if c.running {
+		reply := make(chan error, 1)
+		c.update <- entryUpdate{id: id, msg: msg, reschedule: reschedule, apply: apply, reply: reply}
+		return <-reply
+	}
+	e := c.findEntry(id)
+	if e == nil {
+		return ErrEntryNotFound
+	}
+	apply(e)
+	c.logger.Info(msg, "entry", id, "name", e.Name())
+	return nil
+}
+
+// entryUpdate is a change to an entry applied by the scheduling loop.
+type entryUpdate struct {
+	id         EntryID
+	msg        string
+	reschedule bool
+	apply      func(e *Entry)
+	reply      chan error
+}
+
+// Subscribe registers a listener for the events emitted by the Cron, see
+// EventListener. It returns a func that unsubscribes the listener.
+func (c *Cron) Subscribe(listener EventListener) (unsubscribe func()) {
+	return c.events.subscribe(listener)
 }
 
 // Start the cron scheduler in its own goroutine, or no-op if already started.
@@ -181,6 +423,8 @@ func (c *Cron) Start() {
 		return
 	}
 	c.running = true
+	c.stopping = make(chan struct{})
+	c.jobCtx, c.cancelJobs = context.WithCancel(c.ctx)
 	go c.run()
 }
 
@@ -192,6 +436,8 @@ func (c *Cron) Run() {
 		return
 	}
 	c.running = true
+	c.stopping = make(chan struct{})
+	c.jobCtx, c.cancelJobs = context.WithCancel(c.ctx)
 	c.runningMu.Unlock()
 	c.run()
 }
@@ -203,61 +449,89 @@ func (c *Cron) run() {
 
 	// Figure out the next activation times for each entry.
 	now := c.now()
-	for _, entry := range c.entries {
-		entry.next = entry.schedule.Next(now)
-		c.logger.Info("schedule", "now", now, "entry", entry.ID(), "next", entry.next)
+	c.events.publish(SchedulerStarted{Time: now})
+	for _, entry := range c.entries.sorted() {
+		entry.scheduleNext(now)
+		c.entries.fix(entry)
+		c.debug("schedule", "now", now, "entry", entry.ID(), "name", entry.Name(), "next", entry.next)
+		c.events.publish(JobScheduled{Time: now, Entry: entry.snapshot()})
 	}
 
 	for {
 		// Determine the next entry to run.
-		sort.Sort(byTime(c.entries))
-
-		var timer *time.Timer
-		if len(c.entries) == 0 || c.entries[0].next.IsZero() {
+		var timer Timer
+		if first := c.entries.first(); first == nil || first.next.IsZero() {
 			// If there are no entries yet, just sleep - it still handles new entries
 			// and stop requests.
-			timer = time.NewTimer(100000 * time.Hour)
+			timer = c.clock.NewTimer(100000 * time.Hour)
 		} else {
-			timer = time.NewTimer(c.entries[0].next.Sub(now))
+			timer = c.clock.NewTimer(first.next.Sub(now))
 		}
 
 		for {
 			select {
-			case now = <-timer.C:
+			case now = <-timer.C():
 				now = now.In(c.location)
-				c.logger.Info("wake", "now", now)
+				c.debug("wake", "now", now)
 
 				// Run every entry whose next time was less than now
-				for _, e := range c.entries {
-					if e.next.After(now) || e.next.IsZero() {
+				for {
+					e := c.entries.first()
+					if e == nil || e.next.After(now) || e.next.IsZero() {
 						break
 					}
-					c.startJob(e.WrappedJob())
-					e.prev = e.next
+					due, count := dueActivations(e.schedule, e.next, now)
+					runs := c.misfirePolicy(e)(due, now)
+					missed := count - len(runs)
 					e.next = e.schedule.Next(now)
-					c.logger.Info("run", "now", now, "entry", e.ID(), "next", e.next)
+					c.entries.fix(e)
+					for _, activation := range runs {
+						c.startJob(e, TriggerSchedule, activation, missed)
+					}
+					if len(runs) == 0 {
+						c.logger.Info("skip", "now", now, "entry", e.ID(), "name", e.Name(), "reason", SkipReasonMisfire,
+							"missed", missed, "next", e.next)
+						c.events.publish(JobSkipped{Time: now, Entry: e.snapshot(), Reason: SkipReasonMisfire})
+					} else {
+						c.debug("run", "now", now, "entry", e.ID(), "name", e.Name(), "missed", missed, "next", e.next)
+					}
+					c.events.publish(JobScheduled{Time: now, Entry: e.snapshot()})
 				}
 
 			case newEntry := <-c.add:
 				timer.Stop()
 				now = c.now()
-				newEntry.next = newEntry.schedule.Next(now)
-				c.entries = append(c.entries, newEntry)
-				c.logger.Info("added", "now", now, "entry", newEntry.ID(), "next", newEntry.next)
+				newEntry.scheduleNext(now)
+				c.entries.add(newEntry)
+				c.logger.Info("added", "now", now, "entry", newEntry.ID(), "name", newEntry.Name(), "next", newEntry.next)
+				c.events.publish(EntryAdded{Time: now, Entry: newEntry.snapshot()})
+				c.events.publish(JobScheduled{Time: now, Entry: newEntry.snapshot()})
+
+			case u := <-c.update:
+				timer.Stop()
+				now = c.now()
+				u.reply <- c.applyUpdate(u, now)
 
 			case replyChan := <-c.snapshot:
 				replyChan <- c.entrySnapshot()
 				continue
 
+			case l := <-c.lookup:
+				l.reply <- c.entryByID(l.id)
+				continue
+
 			case <-c.stop:
 				timer.Stop()
 				c.logger.Info("stop")
+				c.events.publish(SchedulerStopped{Time: c.now()})
 				return
 
 			case id := <-c.remove:
 				timer.Stop()
 				now = c.now()
-				c.removeEntry(id)
+				if c.removeEntry(id) {
+					c.events.publish(EntryRemoved{Time: now, EntryID: id})
+				}
 				c.logger.Info("removed", "entry", id)
 			}
 
@@ -266,26 +540,160 @@ func (c *Cron) run() {
 	}
 }
 
-// startJob runs the given job in a new goroutine.
-func (c *Cron) startJob(j Job) {
+// misfirePolicy returns the misfire policy of the entry, or the one of the Cron
+// if the entry has none.
+func (c *Cron) misfirePolicy(e *Entry) MisfirePolicy {
+	if e.misfire != nil {
+		return e.misfire
+	}
+	return c.misfire
+}
+
+// startJob runs the job of the given entry in a new goroutine, subject to the
+// limit of concurrent jobs, see WithMaxConcurrentJobs. The entry's previous
+// activation is set to the given one, unless it is zero, once the run has been
+// started or queued.
+func (c *Cron) startJob(entry *Entry, trigger Trigger, activation time.Time, missed int) {
+	job, snapshot, skipped := entry.WrappedJob(), entry.snapshot(), entry.snapshot()
+	if !activation.IsZero() {
+		snapshot.prev = activation
+	}
+	dispatch := func(fn func()) {
+		if !activation.IsZero() {
+			entry.prev = activation
+		}
+		c.goJob(fn)
+	}
+	ctx := withRunEntry(withMissedRuns(withTrigger(c.jobCtx, trigger), missed), snapshot)
+	run := func() {
+		defer c.releaseSlot()
+		c.trackJob(snapshot.ID(), 1)
+		defer c.trackJob(snapshot.ID(), -1)
+
+		start := c.now()
+		c.events.publish(JobStarted{Time: start, Entry: snapshot, Trigger: trigger})
+		err := job.Run(ctx)
+		end := c.now()
+		c.events.publish(JobFinished{
+			Time:     end,
+			Entry:    snapshot,
+			Trigger:  trigger,
+			Duration: end.Sub(start),
+			Err:      err,
+		})
+
+		if err != nil {
+			c.handleError(ctx, snapshot, err)
+		}
+	}
+
+	if c.slots == nil || c.tryAcquireSlot() {
+		dispatch(run)
+		return
+	}
+
+	switch c.concurrency.kind {
+	case concurrencyBlock:
+		if !c.waitSlot(ctx, c.stopping) {
+			c.skipJob(skipped, SkipReasonCanceled)
+			return
+		}
+		dispatch(run)
+
+	case concurrencyQueue:
+		if !c.enqueueJob() {
+			c.skipJob(skipped, SkipReasonQueueFull)
+			return
+		}
+		dispatch(func() {
+			acquired := c.waitSlot(ctx, nil)
+			c.dequeueJob()
+			if !acquired {
+				c.skipJob(snapshot, SkipReasonCanceled)
+				return
+			}
+			run()
+		})
+
+	default:
+		c.skipJob(skipped, SkipReasonConcurrencyLimit)
+	}
+}
+
+// goJob runs fn in a new goroutine tracked by the job waiter.
+func (c *Cron) goJob(fn func()) {
 	c.jobWaiter.Add(1)
 	go func() {
 		defer c.jobWaiter.Done()
-		j.Run(c.ctx) //nolint:errcheck
+		fn()
 	}()
 }
 
+// skipJob reports a job run of the given entry that has not been run.
+func (c *Cron) skipJob(entry *Entry, reason string) {
+	now := c.now()
+	c.logger.Info("skip", "now", now, "entry", entry.ID(), "name", entry.Name(), "reason", reason)
+	c.events.publish(JobSkipped{Time: now, Entry: entry, Reason: reason})
+}
+
+// trackJob adds delta to the number of running jobs of the given entry.
+func (c *Cron) trackJob(id EntryID, delta int) {
+	c.inflightMu.Lock()
+	defer c.inflightMu.Unlock()
+	c.inflight[id] += delta
+	if c.inflight[id] <= 0 {
+		delete(c.inflight, id)
+	}
+}
+
+// runningEntries returns the ids of the entries with running jobs, in order.
+func (c *Cron) runningEntries() []EntryID {
+	c.inflightMu.Lock()
+	defer c.inflightMu.Unlock()
+	ids := make([]EntryID, 0, len(c.inflight))
+	for id := range c.inflight {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// handleError passes the error of a job run to the configured ErrorHandler,
+// or logs it if none is configured. The entry is the snapshot taken when the
+// run started, since the scheduling loop keeps updating the live one.
+func (c *Cron) handleError(ctx context.Context, entry *Entry, err error) {
+	if c.errHandler != nil {
+		c.errHandler(ctx, entry, err)
+		return
+	}
+	c.logger.Error(err, "job failed", "entry", entry.ID(), "name", entry.Name())
+}
+
+// debug logs a routine message of the scheduler with Debug if the logger is a
+// DebugLogger, or with Info otherwise.
+func (c *Cron) debug(msg string, keysAndValues ...any) {
+	if d, ok := c.logger.(DebugLogger); ok {
+		d.Debug(msg, keysAndValues...)
+		return
+	}
+	c.logger.Info(msg, keysAndValues...)
+}
+
 // now returns current time in c location
 func (c *Cron) now() time.Time {
-	return time.Now().In(c.location)
+	return c.clock.Now().In(c.location)
 }
 
 // Stop stops the cron scheduler if it is running; otherwise it does nothing.
 // A context is returned so the caller can wait for running jobs to complete.
+// Use Shutdown to also cancel the context passed to the running jobs.
 func (c *Cron) Stop() context.Context {
 	c.runningMu.Lock()
 	defer c.runningMu.Unlock()
 	if c.running {
+		// Release the scheduling loop if it is waiting for a slot, see
+		// ConcurrencyBlock, so that it can receive the stop request.
+		close(c.stopping)
 		c.stop <- struct{}{}
 		c.running = false
 	}
@@ -297,26 +705,105 @@ func (c *Cron) Stop() context.Context {
 	return ctx
 }
 
+// Shutdown stops the cron scheduler if it is running, cancels the context
+// passed to the running jobs and waits for them to return.
+//
+// If ctx is done before the jobs have returned, Shutdown returns a
+// *ShutdownError reporting the entries that were still running.
+func (c *Cron) Shutdown(ctx context.Context) error {
+	// Stopping may wait for the scheduling loop, which may itself wait for a
+	// running job, so it must not outlast ctx either.
+	stopped := make(chan context.Context, 1)
+	go func() {
+		done := c.Stop()
+
+		c.runningMu.Lock()
+		if c.cancelJobs != nil {
+			c.cancelJobs()
+		}
+		c.runningMu.Unlock()
+		stopped <- done
+	}()
+
+	select {
+	case done := <-stopped:
+		select {
+		case <-done.Done():
+			return nil
+		case <-ctx.Done():
+		}
+	case <-ctx.Done():
+	}
+	return &ShutdownError{Err: ctx.Err(), Running: c.runningEntries()}
+}
+
+// ShutdownError is returned by Shutdown when the running jobs have not
+// returned in time.
+type ShutdownError struct {
+	// Err is the error of the context passed to Shutdown.
+	Err error
+
+	// Running holds the ids of the entries whose jobs were still running.
+	Running []EntryID
+}
+
+func (e *ShutdownError) Error() string {
+	return fmt.Sprintf("cron: shutdown: %v, entries still running: %v", e.Err, e.Running)
+}
+
+func (e *ShutdownError) Unwrap() error {
+	return e.Err
+}
+
 // IsRunning returns true if the cron scheduler is started.
 func (c *Cron) IsRunning() bool {
 	return c.running
 }
 
-// entrySnapshot returns a copy of the current cron entry list.
+// entrySnapshot returns a copy of the current cron entry list, ordered by
+// next activation time.
 func (c *Cron) entrySnapshot() []Entry {
-	entries := make([]Entry, len(c.entries))
-	for i, e := range c.entries {
+	sorted := c.entries.sorted()
+	entries := make([]Entry, len(sorted))
+	for i, e := range sorted {
 		entries[i] = *e
 	}
 	return entries
 }
 
-func (c *Cron) removeEntry(id EntryID) {
-	var entries []*Entry
-	for _, e := range c.entries {
-		if e.ID() != id {
-			entries = append(entries, e)
-		}
+// entryByID returns a copy of the entry with the given id, or the zero entry
+// if it couldn't be found.
+func (c *Cron) entryByID(id EntryID) Entry {
+	if e := c.entries.get(id); e != nil {
+		return *e
 	}
-	c.entries = entries
+	return Entry{}
+}
+
+// applyUpdate applies the change to its entry from the scheduling loop.
+func (c *Cron) applyUpdate(u entryUpdate, now time.Time) error {
+	e := c.findEntry(u.id)
+	if e == nil {
+		return ErrEntryNotFound
+	}
+	u.apply(e)
+	if !u.reschedule {
+		c.logger.Info(u.msg, "now", now, "entry", e.ID(), "name", e.Name())
+		return nil
+	}
+	e.scheduleNext(now)
+	c.entries.fix(e)
+	c.logger.Info(u.msg, "now", now, "entry", e.ID(), "name", e.Name(), "next", e.next)
+	c.events.publish(JobScheduled{Time: now, Entry: e.snapshot()})
+	return nil
+}
+
+// findEntry returns the entry with the given id, or nil if it couldn't be found.
+func (c *Cron) findEntry(id EntryID) *Entry {
+	return c.entries.get(id)
+}
+
+// removeEntry removes the entry with the given id, reporting whether it was found.
+func (c *Cron) removeEntry(id EntryID) bool {
+	return c.entries.remove(id)
 }
@@ -4,12 +4,15 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"log"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // Many tests schedule a job for every second, and then wait at most a second
@@ -352,6 +355,52 @@ func TestCron_ScheduleWithMiddleware(t *testing.T) {
 	assert.Len(t, ch, 6)
 }
 
+func TestCron_ErrorHandler(t *testing.T) {
+	t.Run("default logs the error", func(t *testing.T) {
+		var buf syncWriter
+		cron := New(
+			WithParser(secondParser),
+			WithLogger(PrintfLogger(log.New(&buf, "", log.LstdFlags))),
+		)
+		id, _ := cron.AddFunc("* * * * * ?", func(context.Context) error {
+			return assert.AnError
+		})
+		cron.Start()
+		defer cron.Stop()
+
+		assert.Eventually(t, func() bool {
+			return strings.Contains(buf.String(), fmt.Sprintf("job failed, error=%v, entry=%d", assert.AnError, id))
+		}, 2*OneSecond, 10*time.Millisecond)
+	})
+
+	t.Run("custom handler", func(t *testing.T) {
+		ch := make(chan EntryID, 1)
+		cron := New(
+			WithParser(secondParser),
+			WithErrorHandler(func(ctx context.Context, entry *Entry, err error) {
+				assert.NotNil(t, ctx)
+				assert.Equal(t, assert.AnError, err)
+				assert.False(t, entry.Prev().IsZero())
+				assert.True(t, entry.Next().After(entry.Prev()))
+				ch <- entry.ID()
+			}),
+		)
+		_, _ = cron.AddFunc("* * * * * ?", func(context.Context) error { return nil })
+		id, _ := cron.AddFunc("* * * * * ?", func(context.Context) error {
+			return assert.AnError
+		})
+		cron.Start()
+		defer cron.Stop()
+
+		select {
+		case <-time.After(OneSecond):
+			t.Fatal("expected error handler called")
+		case actual := <-ch:
+			assert.Equal(t, id, actual)
+		}
+	})
+}
+
 func TestCron_Use(t *testing.T) {
 	cron := New()
 	assert.Len(t, cron.middlewares, 0)
@@ -365,7 +414,40 @@ func TestCron_Use(t *testing.T) {
 	assert.Len(t, cron.middlewares, 3)
 }
 
+func TestCron_UseWithEntryMiddlewares(t *testing.T) {
+	var calls []string
+	middleware := func(name string) Middleware {
+		return func(next Job) Job {
+			return JobFunc(func(ctx context.Context) error {
+				calls = append(calls, name)
+				return next.Run(ctx)
+			})
+		}
+	}
+
+	cron := New()
+	cron.Use(middleware("cron"))
+	id, err := cron.AddEntry("@yearly", NoopJob{},
+		WithEntryMiddlewares(middleware("replaced")),
+		WithEntryMiddlewares(middleware("entry")),
+	)
+	require.NoError(t, err)
+
+	entry := cron.Entry(id)
+	require.NoError(t, entry.WrappedJob().Run(context.Background()))
+	assert.Equal(t, []string{"cron", "entry"}, calls)
+}
+
 // Test that the cron is run in the local time zone (as opposed to UTC).
+func TestCron_Now(t *testing.T) {
+	loc, err := time.LoadLocation("Atlantic/Cape_Verde")
+	require.NoError(t, err)
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cron := New(WithClock(newManualClock(base)), WithLocation(loc))
+	assert.Equal(t, base.In(loc), cron.Now())
+}
+
 func TestLocalTimezone(t *testing.T) {
 	wg := &sync.WaitGroup{}
 	wg.Add(2)
@@ -766,6 +848,322 @@ func TestStopAndWait(t *testing.T) {
 	})
 }
 
+func TestCron_PauseResume(t *testing.T) {
+	t.Run("unknown entry", func(t *testing.T) {
+		cron := newWithSeconds()
+		assert.ErrorIs(t, cron.Pause(1), ErrEntryNotFound)
+		assert.ErrorIs(t, cron.Resume(1), ErrEntryNotFound)
+
+		cron.Start()
+		defer cron.Stop()
+		assert.ErrorIs(t, cron.Pause(1), ErrEntryNotFound)
+		assert.ErrorIs(t, cron.Resume(1), ErrEntryNotFound)
+	})
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newCron := func(clock *manualClock) *Cron {
+		return New(WithParser(secondParser), WithMiddleware(), WithClock(clock), WithLocation(time.UTC))
+	}
+
+	t.Run("paused before start", func(t *testing.T) {
+		ch := make(chan struct{}, 10)
+		clock := newManualClock(base)
+		cron := newCron(clock)
+		id, _ := cron.AddFunc("* * * * * ?", func(context.Context) error {
+			ch <- struct{}{}
+			return nil
+		})
+		require.NoError(t, cron.Pause(id))
+		cron.Start()
+		defer cron.Stop()
+
+		entry := cron.Entry(id)
+		assert.True(t, entry.Paused())
+		assert.True(t, entry.Next().IsZero())
+
+		clock.fire(base.Add(time.Second))
+		entry = cron.Entry(id)
+		assert.True(t, entry.Prev().IsZero())
+
+		require.NoError(t, cron.Resume(id))
+		entry = cron.Entry(id)
+		assert.False(t, entry.Paused())
+		assert.Equal(t, base.Add(2*time.Second), entry.Next())
+
+		clock.fire(base.Add(2 * time.Second))
+		<-ch
+		entry = cron.Entry(id)
+		assert.Equal(t, base.Add(2*time.Second), entry.Prev())
+	})
+
+	t.Run("paused while running keeps the entry", func(t *testing.T) {
+		ch := make(chan struct{}, 10)
+		clock := newManualClock(base)
+		cron := newCron(clock)
+		cron.Start()
+		defer cron.Stop()
+		id, _ := cron.AddFunc("* * * * * ?", func(context.Context) error {
+			ch <- struct{}{}
+			return nil
+		})
+
+		clock.fire(base.Add(time.Second))
+		<-ch
+		require.NoError(t, cron.Pause(id))
+		entry := cron.Entry(id)
+		assert.True(t, entry.Paused())
+		assert.Equal(t, base.Add(time.Second), entry.Prev())
+
+		clock.fire(base.Add(2 * time.Second))
+		entry = cron.Entry(id)
+		assert.Equal(t, base.Add(time.Second), entry.Prev())
+
+		require.NoError(t, cron.Resume(id))
+		entry = cron.Entry(id)
+		assert.False(t, entry.Paused())
+		clock.fire(base.Add(3 * time.Second))
+		<-ch
+		entry = cron.Entry(id)
+		assert.Equal(t, id, entry.ID())
+		assert.Equal(t, base.Add(3*time.Second), entry.Prev())
+		assert.Empty(t, ch)
+	})
+}
+
+func TestCron_Reschedule(t *testing.T) {
+	t.Run("unknown entry", func(t *testing.T) {
+		cron := newWithSeconds()
+		assert.ErrorIs(t, cron.Reschedule(1, Every(time.Second)), ErrEntryNotFound)
+		assert.ErrorIs(t, cron.RescheduleSpec(1, "* * * * * ?"), ErrEntryNotFound)
+		assert.ErrorIs(t, cron.ReplaceJob(1, NoopJob{}), ErrEntryNotFound)
+	})
+
+	t.Run("invalid spec", func(t *testing.T) {
+		cron := newWithSeconds()
+		id := cron.Schedule(Every(time.Hour), NoopJob{})
+		assert.Error(t, cron.RescheduleSpec(id, "this will not parse"))
+	})
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newCron := func(clock *manualClock) *Cron {
+		return New(WithParser(secondParser), WithMiddleware(), WithClock(clock), WithLocation(time.UTC))
+	}
+
+	t.Run("before start", func(t *testing.T) {
+		ch := make(chan struct{}, 10)
+		clock := newManualClock(base)
+		cron := newCron(clock)
+		id, _ := cron.AddFunc("0 0 0 1 1 ?", func(context.Context) error {
+			ch <- struct{}{}
+			return nil
+		})
+		require.NoError(t, cron.RescheduleSpec(id, "* * * * * ?"))
+		cron.Start()
+		defer cron.Stop()
+
+		entry := cron.Entry(id)
+		assert.Equal(t, base.Add(time.Second), entry.Next())
+		clock.fire(base.Add(time.Second))
+		<-ch
+	})
+
+	t.Run("while running", func(t *testing.T) {
+		ch := make(chan string, 10)
+		clock := newManualClock(base)
+		cron := newCron(clock)
+		id, _ := cron.AddFunc("0 0 0 1 1 ?", func(context.Context) error {
+			ch <- "old"
+			return nil
+		})
+		cron.Start()
+		defer cron.Stop()
+
+		entry := cron.Entry(id)
+		assert.Equal(t, base.AddDate(1, 0, 0), entry.Next())
+		require.NoError(t, cron.Reschedule(id, Every(time.Second)))
+		entry = cron.Entry(id)
+		assert.Equal(t, base.Add(time.Second), entry.Next())
+
+		clock.fire(base.Add(time.Second))
+		assert.Equal(t, "old", <-ch)
+		require.NoError(t, cron.ReplaceJob(id, JobFunc(func(ctx context.Context) error {
+			entry, ok := EntryFromContext(ctx)
+			assert.True(t, ok)
+			assert.Equal(t, id, entry.ID())
+			ch <- "new"
+			return nil
+		})))
+		entry = cron.Entry(id)
+		assert.Equal(t, base.Add(2*time.Second), entry.Next())
+
+		clock.fire(base.Add(2 * time.Second))
+		assert.Equal(t, "new", <-ch)
+		assert.Len(t, cron.Entries(), 1)
+	})
+}
+
+func TestCron_RunNow(t *testing.T) {
+	t.Run("unknown entry", func(t *testing.T) {
+		cron := newWithSeconds()
+		assert.ErrorIs(t, cron.RunNow(1), ErrEntryNotFound)
+
+		cron.Start()
+		defer cron.Stop()
+		assert.ErrorIs(t, cron.RunNow(1), ErrEntryNotFound)
+	})
+
+	for _, started := range []bool{false, true} {
+		t.Run(fmt.Sprintf("started=%v", started), func(t *testing.T) {
+			ch := make(chan Trigger, 1)
+			release := make(chan struct{})
+			cron := newWithSeconds()
+			cron.Use(func(job Job) Job {
+				return JobFunc(func(ctx context.Context) error {
+					ch <- TriggerFromContext(ctx)
+					return job.Run(ctx)
+				})
+			})
+			id, _ := cron.AddFunc("0 0 0 1 1 ?", func(ctx context.Context) error {
+				entry, ok := EntryFromContext(ctx)
+				assert.True(t, ok)
+				assert.True(t, entry.Valid())
+				<-release
+				return nil
+			})
+			if started {
+				cron.Start()
+			}
+			entry := cron.Entry(id)
+			next := entry.Next()
+
+			require.NoError(t, cron.RunNow(id))
+			assert.Equal(t, TriggerManual, <-ch)
+			entry = cron.Entry(id)
+			assert.Equal(t, next, entry.Next())
+
+			// the manual run is waited for like a scheduled one
+			ctx := cron.Stop()
+			select {
+			case <-ctx.Done():
+				t.Fatal("expected stop waits for the manual run")
+			case <-time.After(10 * time.Millisecond):
+			}
+			close(release)
+			<-ctx.Done()
+		})
+	}
+}
+
+func TestCron_NamedEntries(t *testing.T) {
+	var buf syncWriter
+	ch := make(chan string, 10)
+	cron := New(WithParser(secondParser), WithLogger(VerbosePrintfLogger(log.New(&buf, "", 0))))
+
+	_, err := cron.AddEntry("this will not parse", NoopJob{}, WithEntryName("invalid"))
+	assert.Error(t, err)
+
+	id, err := cron.AddEntry("* * * * * ?", JobFunc(func(ctx context.Context) error {
+		entry, ok := EntryFromContext(ctx)
+		assert.True(t, ok)
+		ch <- entry.Name()
+		return nil
+	}), WithEntryName("report"))
+	require.NoError(t, err)
+
+	_, err = cron.ScheduleEntry(Every(time.Hour), NoopJob{}, WithEntryName("report"))
+	assert.ErrorIs(t, err, ErrDuplicateEntryName)
+	assert.Len(t, cron.Entries(), 1)
+
+	hourly, err := cron.ScheduleEntry(Every(time.Hour), NoopJob{}, WithEntryName("hourly"))
+	require.NoError(t, err)
+	assert.NotEqual(t, id, hourly)
+
+	entry := cron.EntryByName("report")
+	assert.Equal(t, id, entry.ID())
+	assert.Equal(t, "report", entry.Name())
+	entry = cron.EntryByName("unknown")
+	assert.False(t, entry.Valid())
+
+	cron.Start()
+	defer cron.Stop()
+
+	assert.Equal(t, "report", <-ch)
+	assert.Contains(t, buf.String(), "run, now=")
+	assert.Contains(t, buf.String(), fmt.Sprintf("entry=%d, name=report", id))
+
+	assert.ErrorIs(t, cron.RemoveByName("unknown"), ErrEntryNotFound)
+	require.NoError(t, cron.RemoveByName("hourly"))
+	entry = cron.EntryByName("hourly")
+	assert.False(t, entry.Valid())
+	entry = cron.Entry(hourly)
+	assert.False(t, entry.Valid())
+
+	// the name can be reused once the entry is removed
+	_, err = cron.ScheduleEntry(Every(time.Hour), NoopJob{}, WithEntryName("hourly"))
+	assert.NoError(t, err)
+	cron.Remove(id)
+	_, err = cron.AddEntry("@daily", NoopJob{}, WithEntryName("report"))
+	assert.NoError(t, err)
+}
+
+func TestCron_Shutdown(t *testing.T) {
+	t.Run("not started", func(t *testing.T) {
+		cron := newWithSeconds()
+		assert.NoError(t, cron.Shutdown(context.Background()))
+	})
+
+	t.Run("cancels the context of running jobs", func(t *testing.T) {
+		type key struct{}
+		started := make(chan struct{})
+		cron := New(WithParser(secondParser), WithContext(context.WithValue(context.Background(), key{}, "value")))
+		cron.AddFunc("* * * * * ?", func(ctx context.Context) error { //nolint:errcheck
+			assert.Equal(t, "value", ctx.Value(key{}))
+			select {
+			case started <- struct{}{}:
+				<-ctx.Done()
+			default:
+			}
+			return ctx.Err()
+		})
+		cron.Start()
+		<-started
+
+		ctx, cancel := context.WithTimeout(context.Background(), OneSecond)
+		defer cancel()
+		assert.NoError(t, cron.Shutdown(ctx))
+		assert.False(t, cron.IsRunning())
+	})
+
+	t.Run("reports the jobs still running at the deadline", func(t *testing.T) {
+		started := make(chan struct{})
+		release := make(chan struct{})
+		defer close(release)
+
+		cron := newWithSeconds()
+		cron.AddFunc("0 0 0 1 1 ?", func(context.Context) error { return nil }) //nolint:errcheck
+		id, _ := cron.AddFunc("* * * * * ?", func(context.Context) error {
+			select {
+			case started <- struct{}{}:
+				<-release
+			default:
+			}
+			return nil
+		})
+		cron.Start()
+		<-started
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		err := cron.Shutdown(ctx)
+
+		var shutdownErr *ShutdownError
+		require.ErrorAs(t, err, &shutdownErr)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, []EntryID{id}, shutdownErr.Running)
+		assert.Contains(t, err.Error(), fmt.Sprintf("entries still running: [%d]", id))
+	})
+}
+
 func TestCron_IsRunning(t *testing.T) {
 	c := New()
 
@@ -807,3 +1205,103 @@ func stop(cron *Cron) chan bool {
 func newWithSeconds() *Cron {
 	return New(WithParser(secondParser), WithMiddleware())
 }
+
+// newBenchmarkCron returns a started Cron with n entries.
+func newBenchmarkCron(b *testing.B, n int) (*Cron, []EntryID) {
+	b.Helper()
+	cron := New(WithLogger(DiscardLogger))
+	ids := make([]EntryID, n)
+	for i := range ids {
+		ids[i] = cron.Schedule(Every(time.Duration(i+1)*time.Hour), JobFunc(func(context.Context) error { return nil }))
+	}
+	cron.Start()
+	b.Cleanup(func() { cron.Stop() })
+	return cron, ids
+}
+
+func BenchmarkCron_AddRemove(b *testing.B) {
+	for _, n := range []int{100, 10000} {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			cron, _ := newBenchmarkCron(b, n)
+			job := JobFunc(func(context.Context) error { return nil })
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				cron.Remove(cron.Schedule(Every(time.Minute), job))
+			}
+		})
+	}
+}
+
+func BenchmarkCron_Entry(b *testing.B) {
+	for _, n := range []int{100, 10000} {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			cron, ids := newBenchmarkCron(b, n)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				cron.Entry(ids[i%len(ids)])
+			}
+		})
+	}
+}
+
+func BenchmarkCron_Reschedule(b *testing.B) {
+	for _, n := range []int{100, 10000} {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			cron, ids := newBenchmarkCron(b, n)
+			schedule := Every(time.Minute)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_ = cron.Reschedule(ids[i%len(ids)], schedule)
+			}
+		})
+	}
+}
+
+func BenchmarkEntryStore_Next(b *testing.B) {
+	for _, n := range []int{100, 10000} {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			var (
+				store = newEntryStore()
+				now   = time.Now()
+			)
+			for i := 0; i < n; i++ {
+				e := NewEntry(EntryID(i+1), Every(time.Duration(i+1)*time.Second), nil)
+				e.scheduleNext(now)
+				store.add(e)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				e := store.first()
+				e.next = e.schedule.Next(e.next)
+				store.fix(e)
+			}
+		})
+	}
+}
+
+func TestCron_HashedSpecs(t *testing.T) {
+	parser := NewParser(Minute | Hour | Dom | Month | Dow | Hash)
+	cron := New(WithParser(parser), WithLocation(time.UTC))
+
+	backup, err := cron.AddEntry("H * * * *", NoopJob{}, WithEntryName("backup"))
+	require.NoError(t, err)
+	report, err := cron.AddEntry("H * * * *", NoopJob{}, WithEntryName("report"))
+	require.NoError(t, err)
+
+	expected := func(name string) *SpecSchedule {
+		schedule, err := parser.ParseWithKey("H * * * *", name)
+		require.NoError(t, err)
+		return schedule.(*SpecSchedule)
+	}
+	entry := cron.Entry(backup)
+	assert.Equal(t, expected("backup"), entry.Schedule())
+	entry = cron.Entry(report)
+	assert.Equal(t, expected("report"), entry.Schedule())
+	assert.NotEqual(t, expected("backup"), expected("report"))
+
+	require.NoError(t, cron.RescheduleSpec(backup, "H H * * *"))
+	schedule, err := parser.ParseWithKey("H H * * *", "backup")
+	require.NoError(t, err)
+	entry = cron.Entry(backup)
+	assert.Equal(t, schedule, entry.Schedule())
+}
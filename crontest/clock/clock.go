@@ -0,0 +1,209 @@
+package clock
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/flc1125/go-cron/v4"
+)
+
+// Clock is a manually driven cron.Clock. Time only moves when Advance or Set
+// is called, which makes schedules deterministic in tests. As Advance and Set
+// only fire the timers already armed, wait for the started scheduler to arm
+// its timer with BlockUntil first.
+//
+//	clk := clock.New(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+//	c := cron.New(cron.WithClock(clk), cron.WithMiddleware(clk.Middleware()))
+//	c.Start()
+//	clk.BlockUntil(1)
+//	clk.Advance(time.Hour)
+//	ran := clk.Runs(1)
+type Clock struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	now    time.Time
+	timers []*timer
+	armed  int
+	runs   []cron.EntryID
+}
+
+var _ cron.Clock = (*Clock)(nil)
+
+// New returns a Clock whose current time is now.
+func New(now time.Time) *Clock {
+	c := &Clock{now: now}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Now returns the current time of the clock.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// NewTimer creates a Timer that fires once the clock has been advanced by d.
+func (c *Clock) NewTimer(d time.Duration) cron.Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &timer{
+		when: c.now.Add(d),
+		c:    make(chan time.Time),
+		done: make(chan struct{}),
+	}
+	c.timers = append(c.timers, t)
+	c.armed++
+	c.cond.Broadcast()
+	return t
+}
+
+// BlockUntil blocks until at least n timers are armed and not stopped. A
+// running Cron scheduler has a single armed timer while it waits.
+func (c *Clock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for c.pending() < n {
+		c.cond.Wait()
+	}
+}
+
+// pending returns the number of armed timers that are not stopped. It must be
+// called with c.mu held.
+func (c *Clock) pending() int {
+	n := 0
+	for _, tm := range c.timers {
+		if !tm.stopped() {
+			n++
+		}
+	}
+	return n
+}
+
+// Advance moves the clock forward by d. See Set.
+func (c *Clock) Advance(d time.Duration) {
+	c.Set(c.Now().Add(d))
+}
+
+// Set moves the clock forward to t, firing every armed timer that becomes due
+// on the way in chronological order, see BlockUntil. Each time a timer fires, Set blocks until
+// the receiver has armed a new timer, which the Cron scheduler does once it
+// has dispatched the due entries, so every activation up to t has been
+// started when Set returns.
+func (c *Clock) Set(t time.Time) {
+	for {
+		c.mu.Lock()
+		next := c.nextTimer(t)
+		if next == nil {
+			if t.After(c.now) {
+				c.now = t
+			}
+			c.mu.Unlock()
+			return
+		}
+		if next.when.After(c.now) {
+			c.now = next.when
+		}
+		now, armed := c.now, c.armed
+		c.mu.Unlock()
+
+		select {
+		case next.c <- now:
+			next.Stop()
+		case <-next.done:
+			continue
+		}
+
+		c.mu.Lock()
+		for c.armed == armed {
+			c.cond.Wait()
+		}
+		c.mu.Unlock()
+	}
+}
+
+// nextTimer removes stopped timers and returns the earliest timer due at or
+// before t, or nil if there is none. It must be called with c.mu held.
+func (c *Clock) nextTimer(t time.Time) *timer {
+	timers := c.timers[:0]
+	for _, tm := range c.timers {
+		if !tm.stopped() {
+			timers = append(timers, tm)
+		}
+	}
+	c.timers = timers
+
+	sort.SliceStable(c.timers, func(i, j int) bool {
+		return c.timers[i].when.Before(c.timers[j].when)
+	})
+	for _, tm := range c.timers {
+		if !tm.when.After(t) {
+			return tm
+		}
+	}
+	return nil
+}
+
+// Middleware returns a cron.Middleware that records the entries whose jobs
+// have finished running, see Runs.
+func (c *Clock) Middleware() cron.Middleware {
+	return func(job cron.Job) cron.Job {
+		return cron.JobFunc(func(ctx context.Context) error {
+			defer func() {
+				entry, ok := cron.EntryFromContext(ctx)
+				if !ok {
+					return
+				}
+				c.mu.Lock()
+				defer c.mu.Unlock()
+				c.runs = append(c.runs, entry.ID())
+				c.cond.Broadcast()
+			}()
+			return job.Run(ctx)
+		})
+	}
+}
+
+// Runs blocks until at least n jobs wrapped by Middleware have finished since
+// the previous call, then returns the ids of their entries in completion order.
+func (c *Clock) Runs(n int) []cron.EntryID {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.runs) < n {
+		c.cond.Wait()
+	}
+	runs := c.runs
+	c.runs = nil
+	return runs
+}
+
+type timer struct {
+	when time.Time
+	c    chan time.Time
+	once sync.Once
+	done chan struct{}
+}
+
+func (t *timer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *timer) Stop() bool {
+	stopped := false
+	t.once.Do(func() {
+		close(t.done)
+		stopped = true
+	})
+	return stopped
+}
+
+func (t *timer) stopped() bool {
+	select {
+	case <-t.done:
+		return true
+	default:
+		return false
+	}
+}
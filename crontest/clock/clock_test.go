@@ -0,0 +1,109 @@
+package clock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flc1125/go-cron/v4"
+)
+
+var start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestClock_Timer(t *testing.T) {
+	clk := New(start)
+	assert.Equal(t, start, clk.Now())
+
+	// The receiver arms a new timer after each tick, as the scheduler does.
+	timer := clk.NewTimer(time.Minute)
+	fired := make(chan time.Time, 1)
+	go func() {
+		fired <- <-timer.C()
+		clk.NewTimer(time.Hour).Stop()
+	}()
+
+	clk.Advance(time.Minute + time.Second)
+	assert.Equal(t, start.Add(time.Minute), <-fired)
+	assert.Equal(t, start.Add(time.Minute+time.Second), clk.Now())
+	assert.False(t, timer.Stop())
+
+	stopped := clk.NewTimer(time.Minute)
+	assert.True(t, stopped.Stop())
+	assert.False(t, stopped.Stop())
+	clk.Advance(time.Hour)
+	assert.Equal(t, start.Add(time.Hour+time.Minute+time.Second), clk.Now())
+}
+
+func TestClock_BlockUntil(t *testing.T) {
+	clk := New(start)
+	armed := make(chan struct{})
+	go func() {
+		clk.BlockUntil(2)
+		close(armed)
+	}()
+
+	clk.NewTimer(time.Minute)
+	clk.NewTimer(time.Hour).Stop()
+	select {
+	case <-armed:
+		t.Fatal("expected a stopped timer is not counted")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	clk.NewTimer(time.Hour)
+	<-armed
+}
+
+func TestClock_Example(t *testing.T) {
+	clk := New(start)
+	c := cron.New(cron.WithClock(clk), cron.WithMiddleware(clk.Middleware()))
+	id, err := c.AddFunc("@hourly", func(context.Context) error { return nil })
+	require.NoError(t, err)
+
+	c.Start()
+	defer c.Stop()
+	clk.BlockUntil(1)
+	clk.Advance(time.Hour)
+	assert.Equal(t, []cron.EntryID{id}, clk.Runs(1))
+}
+
+func TestClock_Cron(t *testing.T) {
+	clk := New(start)
+	c := cron.New(
+		cron.WithClock(clk),
+		cron.WithLocation(time.UTC),
+		cron.WithLogger(cron.DiscardLogger),
+		cron.WithMiddleware(clk.Middleware()),
+	)
+
+	hourly, err := c.AddFunc("@hourly", func(context.Context) error { return nil })
+	require.NoError(t, err)
+	daily, err := c.AddFunc("@daily", func(context.Context) error { return nil })
+	require.NoError(t, err)
+
+	c.Start()
+	defer c.Stop()
+
+	clk.BlockUntil(1)
+	clk.Advance(30 * time.Minute)
+	entry := c.Entry(hourly)
+	assert.Equal(t, start.Add(time.Hour), entry.Next())
+
+	clk.Advance(30 * time.Minute)
+	assert.Equal(t, []cron.EntryID{hourly}, clk.Runs(1))
+	entry = c.Entry(hourly)
+	assert.Equal(t, start.Add(time.Hour), entry.Prev())
+
+	clk.Advance(23 * time.Hour)
+	assert.ElementsMatch(t, []cron.EntryID{
+		hourly, hourly, hourly, hourly, hourly, hourly, hourly, hourly,
+		hourly, hourly, hourly, hourly, hourly, hourly, hourly, hourly,
+		hourly, hourly, hourly, hourly, hourly, hourly, hourly, daily,
+	}, clk.Runs(24))
+	entry = c.Entry(daily)
+	assert.Equal(t, start.Add(24*time.Hour), entry.Prev())
+	assert.Equal(t, start.Add(48*time.Hour), entry.Next())
+}
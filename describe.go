@@ -0,0 +1,340 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Describer is implemented by the schedules that can describe themselves in
+// English, such as SpecSchedule and ConstantDelaySchedule.
+type Describer interface {
+	// Describe returns an English description of the activations of the schedule.
+	Describe() string
+}
+
+// Describe returns an English description of the activations of the given
+// schedule, such as "every 15 minutes between 09:00 and 17:59, Monday through
+// Friday", or an empty string if it doesn't implement Describer.
+func Describe(schedule Schedule) string {
+	if d, ok := schedule.(Describer); ok {
+		return d.Describe()
+	}
+	return ""
+}
+
+// Describe returns an English description of the activations of the schedule,
+// followed by its time zone when Location is set and is not time.Local.
+func (s *SpecSchedule) Describe() string {
+	// Without any second, minute, hour or month, the schedule never activates.
+	for _, field := range []struct {
+		bits uint64
+		r    bounds
+	}{{s.Second, seconds}, {s.Minute, minutes}, {s.Hour, hours}, {s.Month, months}} {
+		if len(setValues(field.bits, field.r)) == 0 {
+			return "never"
+		}
+	}
+
+	timeOfDay, daily := s.describeTime()
+	phrases := []string{timeOfDay}
+	if day := s.describeDay(); day != "" {
+		phrases = append(phrases, day)
+	} else if daily {
+		phrases[0] = "every day " + timeOfDay
+	}
+	if s.Month&starBit == 0 {
+		phrases = append(phrases, "in "+joinWords(groupRuns(setValues(s.Month, months), monthName)))
+	}
+	if s.hasYears() {
+		phrases = append(phrases, "in "+joinWords(groupRuns(s.yearValues(), strconv.Itoa)))
+	}
+
+	description := strings.Join(phrases, ", ")
+	if s.Location != nil && s.Location != time.Local {
+		description += " (" + s.Location.String() + ")"
+	}
+	return description
+}
+
+// describeTime describes the activations of the schedule within a day,
+// reporting whether they are a list of times of day.
+func (s *SpecSchedule) describeTime() (string, bool) {
+	var (
+		second, fixedSecond = single(s.Second, seconds)
+		minute, fixedMinute = single(s.Minute, minutes)
+		hourValues          = setValues(s.Hour, hours)
+	)
+
+	// Fixed times of day, e.g. "at 09:00 and 17:00" or "every 2 hours from 00:00 through 22:00".
+	if fixedSecond && fixedMinute {
+		clock := func(hour int) string { return clockTime(hour, minute, second) }
+		switch start, step, ok := progression(hourValues); {
+		case s.Hour&starBit > 0 && minute == 0 && second == 0:
+			return "every hour", false
+		case s.Hour&starBit > 0 && second == 0:
+			return fmt.Sprintf("every hour at minute %d", minute), false
+		case s.Hour&starBit > 0:
+			return fmt.Sprintf("every hour at minute %d and second %d", minute, second), false
+		case ok:
+			return fmt.Sprintf("every %s from %s through %s",
+				plural(step, "hour"), clock(start), clock(hourValues[len(hourValues)-1])), false
+		case len(hourValues) > 2 && step == 1:
+			return fmt.Sprintf("every hour from %s through %s", clock(start), clock(hourValues[len(hourValues)-1])), false
+		default:
+			return "at " + joinWords(mapValues(hourValues, clock)), true
+		}
+	}
+
+	var phrases []string
+	add := func(phrase string) {
+		// Coarser fields qualify the "at" phrases of the finer ones.
+		if n := len(phrases); n > 0 && (strings.HasPrefix(phrases[n-1], "at ") || strings.HasPrefix(phrases[n-1], "of ")) {
+			phrase = strings.TrimPrefix(phrase, "at ")
+			if !strings.HasPrefix(phrase, "between ") && !strings.HasPrefix(phrase, "in ") {
+				phrase = "of " + phrase
+			}
+		}
+		phrases = append(phrases, phrase)
+	}
+
+	switch {
+	case s.Second&starBit > 0:
+		add("every second")
+	case !fixedSecond || second != 0:
+		add(describeUnit(s.Second, seconds, "second"))
+	}
+
+	switch {
+	case s.Minute&starBit > 0:
+		if len(phrases) == 0 || strings.HasPrefix(phrases[0], "at ") {
+			add("every minute")
+		}
+	default:
+		add(describeUnit(s.Minute, minutes, "minute"))
+	}
+
+	if s.Hour&starBit == 0 {
+		if start, step, ok := progression(hourValues); ok {
+			add(fmt.Sprintf("in every %s hour from %02d:00 through %02d:59",
+				ordinal(step), start, hourValues[len(hourValues)-1]))
+		} else if first, last := hourValues[0], hourValues[len(hourValues)-1]; last-first == len(hourValues)-1 {
+			add(fmt.Sprintf("between %02d:00 and %02d:59", first, last))
+		} else {
+			twoDigits := func(hour int) string { return fmt.Sprintf("%02d", hour) }
+			add("in hours " + joinWords(groupRuns(hourValues, twoDigits)))
+		}
+	}
+
+	return strings.Join(phrases, " "), false
+}
+
+// describeUnit describes the values of a bit set of seconds or minutes, e.g.
+// "every 15 minutes", "at minute 30" or "at minutes 0 through 9 and 30".
+func describeUnit(bits uint64, r bounds, unit string) string {
+	values := setValues(bits, r)
+	start, step, ok := progression(values)
+	switch {
+	case ok && start == int(r.min) && values[len(values)-1]+step > int(r.max):
+		return "every " + plural(step, unit)
+	case ok:
+		return fmt.Sprintf("every %s from %s %d through %d", plural(step, unit), unit, start, values[len(values)-1])
+	case len(values) == 1:
+		return fmt.Sprintf("at %s %d", unit, values[0])
+	default:
+		return fmt.Sprintf("at %ss %s", unit, joinWords(groupRuns(values, strconv.Itoa)))
+	}
+}
+
+// describeDay describes the days of month and days of week of the schedule,
+// or returns an empty string if it runs every day.
+func (s *SpecSchedule) describeDay() string {
+	var domItems, dowItems []string
+
+	if s.Dom&starBit == 0 {
+		values := setValues(s.Dom, dom)
+		if start, step, ok := progression(values); ok {
+			domItems = append(domItems, fmt.Sprintf("every %s day from %d through %d",
+				ordinal(step), start, values[len(values)-1]))
+		} else if len(values) > 0 {
+			label := "day "
+			if len(values) > 1 {
+				label = "days "
+			}
+			domItems = append(domItems, label+joinWords(groupRuns(values, strconv.Itoa)))
+		}
+		for n := 0; n < 31; n++ {
+			switch {
+			case s.LastDom&(1<<n) == 0:
+			case n == 0:
+				domItems = append(domItems, "the last day")
+			default:
+				domItems = append(domItems, "the "+ordinal(n+1)+" to last day")
+			}
+		}
+		for n := 0; n <= 31; n++ {
+			switch {
+			case s.WeekdayDom&(1<<n) == 0:
+			case n == 0:
+				domItems = append(domItems, "the last weekday")
+			default:
+				domItems = append(domItems, fmt.Sprintf("the weekday nearest day %d", n))
+			}
+		}
+	}
+
+	if s.Dow&starBit == 0 {
+		dowItems = groupRuns(setValues(s.Dow, dow), weekdayName)
+		for n := 0; n < 7; n++ {
+			if s.LastDow&(1<<n) > 0 {
+				dowItems = append(dowItems, "the last "+weekdayName(n)+" of the month")
+			}
+		}
+		for k := 1; k <= 5; k++ {
+			for n := 0; n < 7; n++ {
+				if s.NthDow&(1<<(7*(k-1)+n)) > 0 {
+					dowItems = append(dowItems, "the "+ordinal(k)+" "+weekdayName(n)+" of the month")
+				}
+			}
+		}
+	}
+
+	var phrases []string
+	if len(domItems) > 0 {
+		phrases = append(phrases, "on "+joinWords(domItems)+" of the month")
+	}
+	if len(dowItems) == 1 && strings.Contains(dowItems[0], " through ") {
+		phrases = append(phrases, dowItems[0])
+	} else if len(dowItems) > 0 {
+		phrases = append(phrases, "on "+joinWords(dowItems))
+	}
+	return strings.Join(phrases, " or ")
+}
+
+// Describe returns an English description of the activations of the schedule,
+// e.g. "every 5 minutes".
+func (schedule ConstantDelaySchedule) Describe() string {
+	for _, unit := range []struct {
+		d    time.Duration
+		name string
+	}{
+		{time.Hour, "hour"},
+		{time.Minute, "minute"},
+		{time.Second, "second"},
+	} {
+		if schedule.Delay >= unit.d && schedule.Delay%unit.d == 0 {
+			return "every " + plural(int(schedule.Delay/unit.d), unit.name)
+		}
+	}
+	return "every " + schedule.Delay.String()
+}
+
+// setValues returns the values set in the bit set within the given bounds.
+func setValues(bits uint64, r bounds) []int {
+	var values []int
+	for i := r.min; i <= r.max; i++ {
+		if bits&(1<<i) > 0 {
+			values = append(values, int(i))
+		}
+	}
+	return values
+}
+
+// single returns the value of a bit set with a single value within the given
+// bounds, reporting whether it has exactly one.
+func single(bits uint64, r bounds) (int, bool) {
+	values := setValues(bits, r)
+	if len(values) != 1 {
+		return 0, false
+	}
+	return values[0], true
+}
+
+// progression returns the first value and the step between the values, and
+// reports whether they are at least three values spaced by a step above one.
+func progression(values []int) (start, step int, ok bool) {
+	if len(values) < 2 {
+		return 0, 0, false
+	}
+	start, step = values[0], values[1]-values[0]
+	for i := 2; i < len(values); i++ {
+		if values[i]-values[i-1] != step {
+			return start, 0, false
+		}
+	}
+	return start, step, step > 1 && len(values) > 2
+}
+
+// groupRuns names the given ascending values, collapsing the runs of at least
+// three consecutive values to "first through last".
+func groupRuns(values []int, name func(int) string) []string {
+	var items []string
+	for i := 0; i < len(values); {
+		j := i
+		for j+1 < len(values) && values[j+1] == values[j]+1 {
+			j++
+		}
+		if j-i >= 2 {
+			items = append(items, name(values[i])+" through "+name(values[j]))
+		} else {
+			j = i
+			items = append(items, name(values[i]))
+		}
+		i = j + 1
+	}
+	return items
+}
+
+func mapValues(values []int, name func(int) string) []string {
+	items := make([]string, len(values))
+	for i, v := range values {
+		items[i] = name(v)
+	}
+	return items
+}
+
+// joinWords joins the items as an English list, e.g. "a, b and c".
+func joinWords(items []string) string {
+	if len(items) <= 1 {
+		return strings.Join(items, "")
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+}
+
+func clockTime(hour, minute, second int) string {
+	if second != 0 {
+		return fmt.Sprintf("%02d:%02d:%02d", hour, minute, second)
+	}
+	return fmt.Sprintf("%02d:%02d", hour, minute)
+}
+
+func plural(n int, unit string) string {
+	if n == 1 {
+		return unit
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
+
+func ordinal(n int) string {
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+		return fmt.Sprintf("%dth", n)
+	case n%10 == 1:
+		return fmt.Sprintf("%dst", n)
+	case n%10 == 2:
+		return fmt.Sprintf("%dnd", n)
+	case n%10 == 3:
+		return fmt.Sprintf("%drd", n)
+	default:
+		return fmt.Sprintf("%dth", n)
+	}
+}
+
+func monthName(month int) string {
+	return time.Month(month).String()
+}
+
+func weekdayName(day int) string {
+	return time.Weekday(day).String()
+}
@@ -0,0 +1,79 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescribe(t *testing.T) {
+	parser := NewParser(SecondOptional | Minute | Hour | Dom | Month | Dow | Descriptor | Quartz)
+	tests := []struct {
+		spec, expected string
+	}{
+		{"*/15 9-17 * * 1-5", "every 15 minutes between 09:00 and 17:59, Monday through Friday"},
+		{"* * * * *", "every minute"},
+		{"* * * * * *", "every second"},
+		{"*/5 * * * * *", "every 5 seconds"},
+		{"30 * * * * *", "at second 30 of every minute"},
+		{"0-9,30 * * * *", "at minutes 0 through 9 and 30"},
+		{"*/10 30 9 * * *", "every 10 seconds at minute 30 between 09:00 and 09:59"},
+		{"5,10 9,17 * * *", "at minutes 5 and 10 in hours 09 and 17"},
+		{"*/15 */2 * * *", "every 15 minutes in every 2nd hour from 00:00 through 22:59"},
+
+		// Times of day.
+		{"0 * * * *", "every hour"},
+		{"30 * * * *", "every hour at minute 30"},
+		{"0 */2 * * *", "every 2 hours from 00:00 through 22:00"},
+		{"0 9-17 * * *", "every hour from 09:00 through 17:00"},
+		{"0 9,17 * * *", "every day at 09:00 and 17:00"},
+		{"15 30 8 * * *", "every day at 08:30:15"},
+
+		// Days, months and descriptors.
+		{"0 0 * * 1,3,5", "at 00:00, on Monday, Wednesday and Friday"},
+		{"0 0 1,15 * 0", "at 00:00, on days 1 and 15 of the month or on Sunday"},
+		{"0 0 */2 * *", "at 00:00, on every 2nd day from 1 through 31 of the month"},
+		{"0 0 * 1-3,7 *", "every day at 00:00, in January through March and July"},
+		{"@hourly", "every hour"},
+		{"@daily", "every day at 00:00"},
+		{"@weekly", "at 00:00, on Sunday"},
+		{"@yearly", "at 00:00, on day 1 of the month, in January"},
+
+		// Quartz modifiers.
+		{"0 0 L-2,15W * *", "at 00:00, on the 3rd to last day and the weekday nearest day 15 of the month"},
+		{"0 0 LW * *", "at 00:00, on the last weekday of the month"},
+		{"0 0 * * 5L,1#2", "at 00:00, on the last Friday of the month and the 2nd Monday of the month"},
+
+		// Time zones.
+		{"CRON_TZ=America/New_York 30 9 * * 1-5", "at 09:30, Monday through Friday (America/New_York)"},
+		{"TZ=UTC 0 0 * * *", "every day at 00:00 (UTC)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			schedule, err := parser.Parse(tt.spec)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, Describe(schedule))
+		})
+	}
+
+	schedule, err := NewParser(Minute | Hour | Dom | Month | Dow | Year).Parse("0 0 1 1 * 2026-2028")
+	require.NoError(t, err)
+	assert.Equal(t, "at 00:00, on day 1 of the month, in January, in 2026 through 2028", Describe(schedule))
+
+	// Empty bit sets don't activate.
+	assert.Equal(t, "never", (&SpecSchedule{Location: time.UTC}).Describe())
+	noHour := &SpecSchedule{Second: 1, Minute: 1, Dom: all(dom), Month: all(months), Dow: all(dow)}
+	assert.Equal(t, "never", noHour.Describe())
+}
+
+func TestConstantDelaySchedule_Describe(t *testing.T) {
+	assert.Equal(t, "every second", Every(time.Second).Describe())
+	assert.Equal(t, "every 5 minutes", Every(5*time.Minute).Describe())
+	assert.Equal(t, "every hour", Every(time.Hour).Describe())
+	assert.Equal(t, "every 90 minutes", Every(90*time.Minute).Describe())
+	assert.Equal(t, "every 1.5s", ConstantDelaySchedule{Delay: 1500 * time.Millisecond}.Describe())
+	assert.Equal(t, "every 90 seconds", Describe(Every(90*time.Second)))
+	assert.Empty(t, Describe(new(ZeroSchedule)))
+}
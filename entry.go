@@ -14,6 +14,10 @@ type Entry struct {
 	// snapshot or remove it.
 	id EntryID
 
+	// name is the optional unique name of this entry, which may be used to
+	// look up a snapshot or remove it.
+	name string
+
 	// schedule on which this job should be run.
 	schedule Schedule
 
@@ -24,6 +28,10 @@ type Entry struct {
 	// prev is the last time this job was run, or the zero time if never.
 	prev time.Time
 
+	// paused is true if the entry has been paused and should not be run
+	// until it is resumed.
+	paused bool
+
 	// wrappedJob is the thing to run when the schedule is activated.
 	wrappedJob Job
 
@@ -34,10 +42,43 @@ type Entry struct {
 
 	// middlewares are the list of middlewares to apply to the job.
 	middlewares []Middleware
+
+	// misfire is the misfire policy of this entry, or nil to use the one of
+	// the Cron.
+	misfire MisfirePolicy
+
+	// callerCtx holds the values of the context of the caller that added
+	// this entry, or nil if unknown.
+	callerCtx context.Context
 }
 
+// EntryOption represents a modification to the default behavior of an Entry.
 type EntryOption func(*Entry)
 
+// WithEntryName sets the name of the entry, which must be unique within a Cron.
+func WithEntryName(name string) EntryOption {
+	return func(e *Entry) {
+		e.name = name
+	}
+}
+
+// WithEntryMisfirePolicy overrides the misfire policy of the Cron for the entry.
+func WithEntryMisfirePolicy(policy MisfirePolicy) EntryOption {
+	return func(e *Entry) {
+		e.misfire = policy
+	}
+}
+
+// WithEntryCallerContext keeps the values of the context of the caller adding
+// the entry, without its cancellation, see Entry.CallerContext.
+func WithEntryCallerContext(ctx context.Context) EntryOption {
+	return func(e *Entry) {
+		e.callerCtx = context.WithoutCancel(ctx)
+	}
+}
+
+// WithEntryMiddlewares sets the middlewares of the entry's job, replacing any
+// previously set ones.
 func WithEntryMiddlewares(middlewares ...Middleware) EntryOption {
 	return func(e *Entry) {
 		e.middlewares = middlewares
@@ -54,26 +95,40 @@ func NewEntry(id EntryID, schedule Schedule, job Job, opts ...EntryOption) *Entr
 	for _, opt := range opts {
 		opt(entry)
 	}
+	entry.wrap()
 
-	// Wrap the job with the entry context.
+	return entry
+}
+
+// wrap builds the wrapped job from the job and the middlewares of the entry.
+func (e *Entry) wrap() {
+	// Wrap the job with the entry context, holding the snapshot of the entry
+	// taken when the Cron started the run if any.
 	middlewares := append([]Middleware{
 		func(job Job) Job {
 			return JobFunc(func(ctx context.Context) error {
+				entry := e
+				if snapshot, ok := ctx.Value(runEntryContextKey{}).(*Entry); ok && snapshot.id == e.id {
+					entry = snapshot
+				}
 				return job.Run(WithEntryContext(ctx, entry))
 			})
 		},
-	}, entry.middlewares...)
+	}, e.middlewares...)
 
 	// Wrap the job with the middlewares.
-	entry.wrappedJob = Chain(middlewares...)(entry.job)
-
-	return entry
+	e.wrappedJob = Chain(middlewares...)(e.job)
 }
 
 func (e *Entry) ID() EntryID {
 	return e.id
 }
 
+// Name returns the name of the entry, or an empty string if it has none.
+func (e *Entry) Name() string {
+	return e.name
+}
+
 // Valid returns true if this is not the zero entry.
 func (e *Entry) Valid() bool { return e.id != 0 }
 
@@ -89,6 +144,11 @@ func (e *Entry) Prev() time.Time {
 	return e.prev
 }
 
+// Paused returns true if the entry is paused.
+func (e *Entry) Paused() bool {
+	return e.paused
+}
+
 func (e *Entry) WrappedJob() Job {
 	return e.wrappedJob
 }
@@ -97,6 +157,31 @@ func (e *Entry) Job() Job {
 	return e.job
 }
 
+// CallerContext returns the context of the caller that added the entry, see
+// WithEntryCallerContext, or context.Background() if it is unknown.
+func (e *Entry) CallerContext() context.Context {
+	if e.callerCtx == nil {
+		return context.Background()
+	}
+	return e.callerCtx
+}
+
+// scheduleNext sets the next activation time of the entry after now, or the
+// zero time if the entry is paused.
+func (e *Entry) scheduleNext(now time.Time) {
+	if e.paused {
+		e.next = time.Time{}
+		return
+	}
+	e.next = e.schedule.Next(now)
+}
+
+// snapshot returns a copy of the entry.
+func (e *Entry) snapshot() *Entry {
+	snapshot := *e
+	return &snapshot
+}
+
 // ------------------------------------ Entry Context ------------------------------------
 
 type entryContextKey struct{}
@@ -106,8 +191,70 @@ func WithEntryContext(ctx context.Context, entry *Entry) context.Context {
 	return context.WithValue(ctx, entryContextKey{}, entry)
 }
 
-// EntryFromContext returns the EntryID from the context.
+// EntryFromContext returns the entry of the job run from the context. For the
+// runs started by the Cron, it is a snapshot of the entry taken when the run
+// started, whose Next() is the activation following the run.
 func EntryFromContext(ctx context.Context) (*Entry, bool) {
 	entry, ok := ctx.Value(entryContextKey{}).(*Entry)
 	return entry, ok
 }
+
+type runEntryContextKey struct{}
+
+// withRunEntry returns a new context with the snapshot of the entry of a run.
+func withRunEntry(ctx context.Context, entry *Entry) context.Context {
+	return context.WithValue(ctx, runEntryContextKey{}, entry)
+}
+
+// ------------------------------------ Trigger Context ------------------------------------
+
+// Trigger describes what caused a job to run.
+type Trigger int
+
+const (
+	// TriggerSchedule means the job has been run by its schedule.
+	TriggerSchedule Trigger = iota
+
+	// TriggerManual means the job has been run on demand, see Cron.RunNow.
+	TriggerManual
+)
+
+func (t Trigger) String() string {
+	switch t {
+	case TriggerSchedule:
+		return "schedule"
+	case TriggerManual:
+		return "manual"
+	default:
+		return "unknown"
+	}
+}
+
+type triggerContextKey struct{}
+
+// withTrigger returns a new context with the given Trigger.
+func withTrigger(ctx context.Context, trigger Trigger) context.Context {
+	return context.WithValue(ctx, triggerContextKey{}, trigger)
+}
+
+// TriggerFromContext returns the Trigger of the job run from the context,
+// which is TriggerSchedule if none is set.
+func TriggerFromContext(ctx context.Context) Trigger {
+	trigger, _ := ctx.Value(triggerContextKey{}).(Trigger)
+	return trigger
+}
+
+type missedRunsContextKey struct{}
+
+// withMissedRuns returns a new context with the given number of missed runs.
+func withMissedRuns(ctx context.Context, missed int) context.Context {
+	return context.WithValue(ctx, missedRunsContextKey{}, missed)
+}
+
+// MissedRunsFromContext returns the number of activations of the entry that
+// have been missed before the job run, see MisfirePolicy. It is a lower bound
+// when there were too many of them to be counted.
+func MissedRunsFromContext(ctx context.Context) int {
+	missed, _ := ctx.Value(missedRunsContextKey{}).(int)
+	return missed
+}
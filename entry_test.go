@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEntry_Attributes(t *testing.T) {
@@ -19,6 +20,16 @@ func TestEntry_Attributes(t *testing.T) {
 	assert.Nil(t, entry.Schedule())
 	assert.Zero(t, entry.Next())
 	assert.Zero(t, entry.Prev())
+	assert.False(t, entry.Paused())
+	assert.Empty(t, entry.Name())
+
+	entry = NewEntry(2, nil, NoopJob{},
+		WithEntryName("name"),
+		WithEntryMiddlewares(NoopMiddleware()),
+		WithEntryMiddlewares(NoopMiddleware(), NoopMiddleware()),
+	)
+	assert.Equal(t, "name", entry.Name())
+	assert.Len(t, entry.middlewares, 2)
 	assert.True(t, entry.Valid())
 }
 
@@ -96,3 +107,32 @@ func TestEntry_ContextUseCron(t *testing.T) {
 	assert.NotNil(t, e2.Load())
 	assert.NotEqual(t, e1.Load().(*Entry).id, e2.Load().(*Entry).id)
 }
+
+func TestEntry_Trigger(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, TriggerSchedule, TriggerFromContext(ctx))
+	assert.Equal(t, TriggerManual, TriggerFromContext(withTrigger(ctx, TriggerManual)))
+
+	assert.Equal(t, "schedule", TriggerSchedule.String())
+	assert.Equal(t, "manual", TriggerManual.String())
+	assert.Equal(t, "unknown", Trigger(-1).String())
+}
+
+func TestEntry_CallerContext(t *testing.T) {
+	type key struct{}
+
+	entry := NewEntry(1, nil, NoopJob{})
+	assert.Equal(t, context.Background(), entry.CallerContext())
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), key{}, "value"))
+	cancel()
+	entry = NewEntry(1, nil, NoopJob{}, WithEntryCallerContext(ctx))
+	assert.Equal(t, "value", entry.CallerContext().Value(key{}))
+	assert.NoError(t, entry.CallerContext().Err())
+
+	cron := New()
+	id, err := cron.AddJobContext(ctx, "@every 1h", NoopJob{})
+	require.NoError(t, err)
+	entry2 := cron.Entry(id)
+	assert.Equal(t, "value", entry2.CallerContext().Value(key{}))
+}
@@ -0,0 +1,145 @@
+package cron
+
+import (
+	"sync"
+	"time"
+)
+
+// Event is emitted by the Cron when its scheduling state changes. It is one of
+// EntryAdded, EntryRemoved, JobScheduled, JobStarted, JobFinished, JobSkipped,
+// SchedulerStarted or SchedulerStopped.
+//
+// The entries carried by the events are snapshots taken when the event occurred.
+type Event interface {
+	event()
+}
+
+// EventListener receives the events emitted by the Cron.
+//
+// Listeners are called synchronously, some of them from the scheduling loop,
+// so they must return quickly and must not call back into the Cron.
+type EventListener func(Event)
+
+// EntryAdded is emitted when an entry has been added to the Cron.
+type EntryAdded struct {
+	Time  time.Time
+	Entry *Entry
+}
+
+// EntryRemoved is emitted when an entry has been removed from the Cron.
+type EntryRemoved struct {
+	Time    time.Time
+	EntryID EntryID
+}
+
+// JobScheduled is emitted when the next activation time of an entry has been
+// computed. Entry.Next() holds the new activation time.
+type JobScheduled struct {
+	Time  time.Time
+	Entry *Entry
+}
+
+// JobStarted is emitted when the job of an entry starts running.
+type JobStarted struct {
+	Time    time.Time
+	Entry   *Entry
+	Trigger Trigger
+}
+
+// JobFinished is emitted when the job of an entry has returned.
+type JobFinished struct {
+	Time     time.Time
+	Entry    *Entry
+	Trigger  Trigger
+	Duration time.Duration
+	Err      error
+}
+
+// JobSkipped is emitted when an activation of an entry has not been run.
+type JobSkipped struct {
+	Time   time.Time
+	Entry  *Entry
+	Reason string
+}
+
+// The reasons of the JobSkipped events emitted by the Cron.
+const (
+	// SkipReasonMisfire means the activation has been missed, see MisfirePolicy.
+	SkipReasonMisfire = "misfire"
+
+	// SkipReasonConcurrencyLimit means the maximum number of concurrent jobs
+	// has been reached, see ConcurrencySkip.
+	SkipReasonConcurrencyLimit = "concurrency limit"
+
+	// SkipReasonQueueFull means the backlog of queued runs is full, see
+	// ConcurrencyQueue.
+	SkipReasonQueueFull = "queue full"
+
+	// SkipReasonCanceled means the Cron has been stopped or shut down while the
+	// run was waiting for a running job to return.
+	SkipReasonCanceled = "canceled"
+)
+
+// SchedulerStarted is emitted when the scheduling loop starts.
+type SchedulerStarted struct {
+	Time time.Time
+}
+
+// SchedulerStopped is emitted when the scheduling loop stops.
+type SchedulerStopped struct {
+	Time time.Time
+}
+
+func (EntryAdded) event()       {}
+func (EntryRemoved) event()     {}
+func (JobScheduled) event()     {}
+func (JobStarted) event()       {}
+func (JobFinished) event()      {}
+func (JobSkipped) event()       {}
+func (SchedulerStarted) event() {}
+func (SchedulerStopped) event() {}
+
+// eventBus dispatches events to the subscribed listeners in subscription order.
+type eventBus struct {
+	mu        sync.RWMutex
+	nextID    int
+	listeners []subscription
+}
+
+type subscription struct {
+	id       int
+	listener EventListener
+}
+
+// subscribe adds the listener and returns a func that removes it.
+func (b *eventBus) subscribe(listener EventListener) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.nextID++
+	id := b.nextID
+	b.listeners = append(b.listeners, subscription{id, listener})
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			for i, s := range b.listeners {
+				if s.id == id {
+					b.listeners = append(b.listeners[:i:i], b.listeners[i+1:]...)
+					return
+				}
+			}
+		})
+	}
+}
+
+// publish sends the event to every listener.
+func (b *eventBus) publish(event Event) {
+	b.mu.RLock()
+	listeners := b.listeners
+	b.mu.RUnlock()
+	for _, s := range listeners {
+		s.listener(event)
+	}
+}
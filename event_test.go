@@ -0,0 +1,122 @@
+package cron
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type eventRecorder struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (r *eventRecorder) listen(event Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *eventRecorder) Events() []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Event(nil), r.events...)
+}
+
+func TestEventBus_Subscribe(t *testing.T) {
+	var (
+		bus   eventBus
+		calls []int
+	)
+	unsubscribe1 := bus.subscribe(func(Event) { calls = append(calls, 1) })
+	unsubscribe2 := bus.subscribe(func(Event) { calls = append(calls, 2) })
+
+	bus.publish(SchedulerStarted{})
+	assert.Equal(t, []int{1, 2}, calls)
+
+	unsubscribe1()
+	unsubscribe1()
+	bus.publish(SchedulerStopped{})
+	assert.Equal(t, []int{1, 2, 2}, calls)
+
+	unsubscribe2()
+	bus.publish(SchedulerStopped{})
+	assert.Equal(t, []int{1, 2, 2}, calls)
+}
+
+func TestCron_Events(t *testing.T) {
+	var recorder eventRecorder
+	cron := New(WithParser(secondParser), WithEventListener(recorder.listen))
+
+	id, err := cron.AddFunc("* * * * * ?", func(context.Context) error {
+		return assert.AnError
+	})
+	require.NoError(t, err)
+	removed := cron.Schedule(Every(time.Hour), NoopJob{})
+	cron.Remove(removed)
+
+	cron.Start()
+	added, err := cron.AddFunc("@yearly", func(context.Context) error { return nil })
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		for _, event := range recorder.Events() {
+			if _, ok := event.(JobFinished); ok {
+				return true
+			}
+		}
+		return false
+	}, 2*OneSecond, 10*time.Millisecond)
+	<-cron.Stop().Done()
+	time.Sleep(10 * time.Millisecond)
+
+	events := recorder.Events()
+	require.GreaterOrEqual(t, len(events), 10)
+	assert.Equal(t, id, events[0].(EntryAdded).Entry.ID())
+	assert.Equal(t, removed, events[1].(EntryAdded).Entry.ID())
+	assert.Equal(t, removed, events[2].(EntryRemoved).EntryID)
+	assert.IsType(t, SchedulerStarted{}, events[3])
+	assert.Equal(t, id, events[4].(JobScheduled).Entry.ID())
+	assert.False(t, events[4].(JobScheduled).Entry.next.IsZero())
+	assert.Equal(t, added, events[5].(EntryAdded).Entry.ID())
+	assert.Equal(t, added, events[6].(JobScheduled).Entry.ID())
+	assert.IsType(t, SchedulerStopped{}, events[len(events)-1])
+
+	var started, finished, scheduled int
+	for _, event := range events[7:] {
+		switch e := event.(type) {
+		case JobStarted:
+			started++
+			assert.Equal(t, id, e.Entry.ID())
+		case JobFinished:
+			finished++
+			assert.Equal(t, id, e.Entry.ID())
+			assert.Equal(t, assert.AnError, e.Err)
+			assert.GreaterOrEqual(t, e.Duration, time.Duration(0))
+		case JobScheduled:
+			scheduled++
+			assert.Equal(t, id, e.Entry.ID())
+		}
+	}
+	assert.GreaterOrEqual(t, started, 1)
+	assert.Equal(t, started, finished)
+	assert.GreaterOrEqual(t, scheduled, started)
+}
+
+func TestCron_Subscribe(t *testing.T) {
+	var recorder eventRecorder
+	cron := New()
+	unsubscribe := cron.Subscribe(recorder.listen)
+
+	id := cron.Schedule(Every(time.Hour), NoopJob{})
+	unsubscribe()
+	cron.Remove(id)
+
+	events := recorder.Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, id, events[0].(EntryAdded).Entry.ID())
+}
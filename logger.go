@@ -23,32 +23,74 @@ type Logger interface {
 	Error(err error, msg string, keysAndValues ...any)
 }
 
+// DebugLogger is a Logger that also logs debug messages. The Cron detects it
+// at runtime and logs its routine per-activation messages, such as "wake",
+// "schedule" and "run", with Debug; with other loggers they are logged with
+// Info. Lifecycle messages such as "start", "stop", "added" and "removed" are
+// always logged with Info.
+type DebugLogger interface {
+	Logger
+	// Debug logs routine messages that are only relevant when debugging.
+	Debug(msg string, keysAndValues ...any)
+}
+
+// LogLevel is the minimum level of the messages logged by a leveled logger,
+// see LeveledPrintfLogger.
+type LogLevel int
+
+const (
+	// LogLevelDebug logs every message.
+	LogLevelDebug LogLevel = iota
+	// LogLevelInfo logs info messages and errors.
+	LogLevelInfo
+	// LogLevelError logs errors only.
+	LogLevelError
+)
+
 // PrintfLogger wraps a Printf-based logger (such as the standard library "log")
 // into an implementation of the Logger interface which logs errors only.
 func PrintfLogger(l interface{ Printf(string, ...any) }) Logger {
-	return printfLogger{l, false}
+	return printfLogger{l, LogLevelError}
 }
 
 // VerbosePrintfLogger wraps a Printf-based logger (such as the standard library
 // "log") into an implementation of the Logger interface which logs everything.
 func VerbosePrintfLogger(l interface{ Printf(string, ...any) }) Logger {
-	return printfLogger{l, true}
+	return printfLogger{l, LogLevelDebug}
+}
+
+// LeveledPrintfLogger wraps a Printf-based logger (such as the standard library
+// "log") into an implementation of the DebugLogger interface which logs the
+// messages at or above the given level. With LogLevelInfo, the lifecycle
+// messages are logged without the routine per-activation ones.
+func LeveledPrintfLogger(l interface{ Printf(string, ...any) }, level LogLevel) DebugLogger {
+	return printfLogger{l, level}
 }
 
 type printfLogger struct {
-	logger  interface{ Printf(string, ...any) }
-	logInfo bool
+	logger interface{ Printf(string, ...any) }
+	level  LogLevel
+}
+
+func (pl printfLogger) Debug(msg string, keysAndValues ...any) {
+	if pl.level <= LogLevelDebug {
+		pl.print(msg, keysAndValues)
+	}
 }
 
 func (pl printfLogger) Info(msg string, keysAndValues ...any) {
-	if pl.logInfo {
-		keysAndValues = formatTimes(keysAndValues)
-		pl.logger.Printf(
-			formatString(len(keysAndValues)),
-			append([]any{msg}, keysAndValues...)...)
+	if pl.level <= LogLevelInfo {
+		pl.print(msg, keysAndValues)
 	}
 }
 
+func (pl printfLogger) print(msg string, keysAndValues []any) {
+	keysAndValues = formatTimes(keysAndValues)
+	pl.logger.Printf(
+		formatString(len(keysAndValues)),
+		append([]any{msg}, keysAndValues...)...)
+}
+
 func (pl printfLogger) Error(err error, msg string, keysAndValues ...any) {
 	keysAndValues = formatTimes(keysAndValues)
 	pl.logger.Printf(
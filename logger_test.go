@@ -0,0 +1,65 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintfLoggers(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		logger func(l *log.Logger) Logger
+		want   string
+	}{
+		{"printf", func(l *log.Logger) Logger { return PrintfLogger(l) },
+			"failed, error=boom, entry=1\n"},
+		{"verbose", func(l *log.Logger) Logger { return VerbosePrintfLogger(l) },
+			"wake, now=2024-01-01T00:00:00Z\nstart\nfailed, error=boom, entry=1\n"},
+		{"debug", func(l *log.Logger) Logger { return LeveledPrintfLogger(l, LogLevelDebug) },
+			"wake, now=2024-01-01T00:00:00Z\nstart\nfailed, error=boom, entry=1\n"},
+		{"info", func(l *log.Logger) Logger { return LeveledPrintfLogger(l, LogLevelInfo) },
+			"start\nfailed, error=boom, entry=1\n"},
+		{"error", func(l *log.Logger) Logger { return LeveledPrintfLogger(l, LogLevelError) },
+			"failed, error=boom, entry=1\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf syncWriter
+			logger := tt.logger(log.New(&buf, "", 0))
+
+			debug, ok := logger.(DebugLogger)
+			assert.True(t, ok)
+			debug.Debug("wake", "now", now)
+			logger.Info("start")
+			logger.Error(errors.New("boom"), "failed", "entry", 1)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestCron_LogLevels(t *testing.T) {
+	var buf syncWriter
+	cron := New(WithParser(secondParser), WithLogger(LeveledPrintfLogger(log.New(&buf, "", 0), LogLevelInfo)))
+	id, err := cron.AddFunc("* * * * * ?", func(ctx context.Context) error { return nil })
+	assert.NoError(t, err)
+
+	cron.Start()
+	time.Sleep(OneSecond)
+	cron.Remove(id)
+	cron.Stop()
+
+	// The lifecycle messages are logged, the routine ones are not.
+	assert.Contains(t, buf.String(), "start")
+	assert.Contains(t, buf.String(), "removed")
+	assert.Contains(t, buf.String(), "stop")
+	assert.NotContains(t, buf.String(), "wake")
+	assert.NotContains(t, buf.String(), "schedule")
+	assert.NotContains(t, buf.String(), "run,")
+}
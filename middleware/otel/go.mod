@@ -2,13 +2,19 @@ module github.com/flc1125/go-cron/middleware/otel/v4
 
 go 1.23.0
 
-replace github.com/flc1125/go-cron/v4 => ../../
+replace (
+	github.com/flc1125/go-cron/crontest/v4 => ../../crontest
+	github.com/flc1125/go-cron/v4 => ../../
+)
 
 require (
+	github.com/flc1125/go-cron/crontest/v4 v4.5.0
 	github.com/flc1125/go-cron/v4 v4.5.0
 	github.com/stretchr/testify v1.10.0
 	go.opentelemetry.io/otel v1.34.0
+	go.opentelemetry.io/otel/metric v1.34.0
 	go.opentelemetry.io/otel/sdk v1.34.0
+	go.opentelemetry.io/otel/sdk/metric v1.34.0
 	go.opentelemetry.io/otel/trace v1.34.0
 )
 
@@ -19,7 +25,6 @@ require (
 	github.com/google/uuid v1.6.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
-	go.opentelemetry.io/otel/metric v1.34.0 // indirect
 	golang.org/x/sys v0.30.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
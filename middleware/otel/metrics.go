@@ -0,0 +1,74 @@
+package otel
+
+import (
+	"context"
+	"time"
+
+	"github.com/flc1125/go-cron/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// metrics holds the instruments recording the runs of the jobs.
+type metrics struct {
+	duration metric.Float64Histogram
+	runs     metric.Int64Counter
+	errors   metric.Int64Counter
+	active   metric.Int64UpDownCounter
+}
+
+// newMetrics creates the instruments with the given meter. Instruments that
+// can't be created are reported to the global error handler and are no-ops.
+func newMetrics(meter metric.Meter) *metrics {
+	var (
+		m   metrics
+		err error
+	)
+	if m.duration, err = meter.Float64Histogram("cron.job.duration",
+		metric.WithDescription("Duration of job runs."),
+		metric.WithUnit("s"),
+	); err != nil {
+		otel.Handle(err)
+	}
+	if m.runs, err = meter.Int64Counter("cron.job.runs",
+		metric.WithDescription("Number of job runs."),
+		metric.WithUnit("{run}"),
+	); err != nil {
+		otel.Handle(err)
+	}
+	if m.errors, err = meter.Int64Counter("cron.job.errors",
+		metric.WithDescription("Number of job runs that returned an error."),
+		metric.WithUnit("{run}"),
+	); err != nil {
+		otel.Handle(err)
+	}
+	if m.active, err = meter.Int64UpDownCounter("cron.job.active",
+		metric.WithDescription("Number of running jobs."),
+		metric.WithUnit("{run}"),
+	); err != nil {
+		otel.Handle(err)
+	}
+	return &m
+}
+
+// measure runs the job of the given entry and records the metrics of the run.
+func (m *metrics) measure(ctx context.Context, entry *cron.Entry, name string, run func(context.Context) error) error {
+	attrs := []attribute.KeyValue{attrJobID.Int(int(entry.ID()))}
+	if name != "" {
+		attrs = append(attrs, attrJobName.String(name))
+	}
+	set := metric.WithAttributeSet(attribute.NewSet(attrs...))
+
+	m.active.Add(ctx, 1, set)
+	start := time.Now()
+	err := run(ctx)
+	m.duration.Record(ctx, time.Since(start).Seconds(), set)
+	m.active.Add(ctx, -1, set)
+
+	m.runs.Add(ctx, 1, set)
+	if err != nil {
+		m.errors.Add(ctx, 1, set)
+	}
+	return err
+}
@@ -0,0 +1,81 @@
+package otel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flc1125/go-cron/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	middleware := New(WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))))
+
+	named := cron.NewEntry(1, nil, &mockJob{t: t, name: "named"}, cron.WithEntryMiddlewares(middleware))
+	failing := cron.NewEntry(2, nil, cron.JobFunc(func(context.Context) error {
+		return assert.AnError
+	}), cron.WithEntryMiddlewares(middleware))
+
+	require.NoError(t, named.WrappedJob().Run(ctx))
+	require.NoError(t, named.WrappedJob().Run(ctx))
+	require.Error(t, failing.WrappedJob().Run(ctx))
+	imsb.Reset()
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	assert.Equal(t, scopeName, rm.ScopeMetrics[0].Scope.Name)
+
+	metrics := make(map[string]metricdata.Metrics)
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m
+	}
+
+	namedAttrs := attribute.NewSet(attrJobID.Int(1), attrJobName.String("named"))
+	failingAttrs := attribute.NewSet(attrJobID.Int(2))
+
+	sums := func(name string) map[attribute.Set]int64 {
+		values := make(map[attribute.Set]int64)
+		for _, dp := range metrics[name].Data.(metricdata.Sum[int64]).DataPoints {
+			values[dp.Attributes] = dp.Value
+		}
+		return values
+	}
+	assert.Equal(t, map[attribute.Set]int64{namedAttrs: 2, failingAttrs: 1}, sums("cron.job.runs"))
+	assert.Equal(t, map[attribute.Set]int64{failingAttrs: 1}, sums("cron.job.errors"))
+	assert.Equal(t, map[attribute.Set]int64{namedAttrs: 0, failingAttrs: 0}, sums("cron.job.active"))
+	assert.False(t, metrics["cron.job.active"].Data.(metricdata.Sum[int64]).IsMonotonic)
+
+	duration := metrics["cron.job.duration"]
+	assert.Equal(t, "s", duration.Unit)
+	counts := make(map[attribute.Set]uint64)
+	for _, dp := range duration.Data.(metricdata.Histogram[float64]).DataPoints {
+		counts[dp.Attributes] = dp.Count
+	}
+	assert.Equal(t, map[attribute.Set]uint64{namedAttrs: 2, failingAttrs: 1}, counts)
+}
+
+func TestMetrics_Active(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	middleware := New(WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))))
+
+	var active int64
+	entry := cron.NewEntry(1, nil, cron.JobFunc(func(ctx context.Context) error {
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, reader.Collect(ctx, &rm))
+		for _, m := range rm.ScopeMetrics[0].Metrics {
+			if m.Name == "cron.job.active" {
+				active = m.Data.(metricdata.Sum[int64]).DataPoints[0].Value
+			}
+		}
+		return nil
+	}), cron.WithEntryMiddlewares(middleware))
+
+	require.NoError(t, entry.WrappedJob().Run(ctx))
+	assert.Equal(t, int64(1), active)
+}
@@ -2,11 +2,14 @@ package otel
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/flc1125/go-cron/v4"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -17,10 +20,14 @@ var (
 	attrJobID       = attribute.Key("cron.job.id")
 	attrJobPrevTime = attribute.Key("cron.job.prev.time")
 	attrJobNextTime = attribute.Key("cron.job.next.time")
+	attrJobTrigger  = attribute.Key("cron.job.trigger")
 )
 
 type options struct {
-	tp trace.TracerProvider
+	tp           trace.TracerProvider
+	mp           metric.MeterProvider
+	callerLink   bool
+	previousLink bool
 }
 
 type Option func(*options)
@@ -31,9 +38,34 @@ func WithTracerProvider(tp trace.TracerProvider) Option {
 	}
 }
 
+// WithMeterProvider sets the meter provider of the job metrics.
+// The default is the global meter provider.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(o *options) {
+		o.mp = mp
+	}
+}
+
+// WithCallerLink links the span of each run to the span of the caller that
+// added the entry, see cron.Cron.AddJobContext and cron.WithEntryCallerContext.
+func WithCallerLink() Option {
+	return func(o *options) {
+		o.callerLink = true
+	}
+}
+
+// WithPreviousRunLink links the span of each run to the span of the previous
+// run of the same entry.
+func WithPreviousRunLink() Option {
+	return func(o *options) {
+		o.previousLink = true
+	}
+}
+
 func newOption(opts ...Option) *options {
 	opt := &options{
 		tp: otel.GetTracerProvider(),
+		mp: otel.GetMeterProvider(),
 	}
 	for _, o := range opts {
 		o(opt)
@@ -41,6 +73,9 @@ func newOption(opts ...Option) *options {
 	return opt
 }
 
+// JobWithName is a job that provides its own name for the spans and metrics.
+// Jobs that don't implement it are named after their entry, see
+// cron.WithEntryName, and entries without a name are not traced.
 type JobWithName interface {
 	cron.Job
 
@@ -48,9 +83,39 @@ type JobWithName interface {
 	Name() string
 }
 
-func New(opts ...Option) cron.Middleware {
+// Tracing traces the runs of the jobs with names, see JobWithName, and
+// records the metrics of the runs of all the jobs.
+type Tracing struct {
+	options  *options
+	tracer   trace.Tracer
+	metrics  *metrics
+	previous *previousRuns
+}
+
+// NewTracing creates the tracer and the job metrics from the configured
+// providers.
+func NewTracing(opts ...Option) *Tracing {
 	o := newOption(opts...)
-	tracer := o.tp.Tracer(scopeName)
+	return &Tracing{
+		options:  o,
+		tracer:   o.tp.Tracer(scopeName),
+		metrics:  newMetrics(o.mp.Meter(scopeName)),
+		previous: newPreviousRuns(),
+	}
+}
+
+// New returns a middleware that traces the runs of the jobs with names, see
+// JobWithName, and records the metrics of the runs of all the jobs.
+//
+// With WithPreviousRunLink, the span contexts of the removed entries are kept
+// by the middleware; use NewTracing and Tracing.Listen to release them.
+func New(opts ...Option) cron.Middleware {
+	return NewTracing(opts...).Middleware()
+}
+
+// Middleware returns a middleware tracing the runs of the jobs.
+func (t *Tracing) Middleware() cron.Middleware {
+	o, tracer, metrics, previous := t.options, t.tracer, t.metrics, t.previous
 	return func(original cron.Job) cron.Job {
 		return cron.JobFunc(func(ctx context.Context) error {
 			entry, ok := cron.EntryFromContext(ctx)
@@ -58,24 +123,48 @@ func New(opts ...Option) cron.Middleware {
 				return original.Run(ctx)
 			}
 
-			job, ok := any(entry.Job()).(JobWithName)
-			if !ok {
-				return original.Run(ctx)
+			name := entry.Name()
+			if job, ok := any(entry.Job()).(JobWithName); ok {
+				name = job.Name()
+			}
+			if name == "" {
+				return metrics.measure(ctx, entry, name, original.Run)
+			}
+
+			var links []trace.Link
+			if o.callerLink {
+				if sc := trace.SpanContextFromContext(entry.CallerContext()); sc.IsValid() {
+					links = append(links, trace.Link{SpanContext: sc})
+				}
+			}
+			if o.previousLink {
+				if sc, ok := previous.get(entry.ID()); ok {
+					links = append(links, trace.Link{SpanContext: sc})
+				}
 			}
 
-			ctx, span := tracer.Start(ctx, "cron "+job.Name(),
+			ctx, span := tracer.Start(ctx, "cron "+name,
 				trace.WithSpanKind(trace.SpanKindInternal),
+				trace.WithLinks(links...),
 			)
 			defer span.End()
+			if o.previousLink {
+				previous.set(entry.ID(), span.SpanContext())
+			}
 
 			span.SetAttributes(
 				attrJobID.Int(int(entry.ID())),
-				attrJobName.String(job.Name()),
-				attrJobPrevTime.String(entry.Prev().String()),
-				attrJobNextTime.String(entry.Next().String()),
+				attrJobName.String(name),
+				attrJobTrigger.String(cron.TriggerFromContext(ctx).String()),
 			)
+			if prev := entry.Prev(); !prev.IsZero() {
+				span.SetAttributes(attrJobPrevTime.String(prev.Format(time.RFC3339Nano)))
+			}
+			if next := entry.Next(); !next.IsZero() {
+				span.SetAttributes(attrJobNextTime.String(next.Format(time.RFC3339Nano)))
+			}
 
-			err := job.Run(ctx)
+			err := metrics.measure(ctx, entry, name, original.Run)
 			if err != nil {
 				span.SetStatus(codes.Error, err.Error())
 				span.RecordError(err)
@@ -85,3 +174,40 @@ func New(opts ...Option) cron.Middleware {
 		})
 	}
 }
+
+// Listen releases the span context of the last run of the removed entries.
+// It is a cron.EventListener, see cron.WithEventListener.
+func (t *Tracing) Listen(event cron.Event) {
+	if e, ok := event.(cron.EntryRemoved); ok {
+		t.previous.delete(e.EntryID)
+	}
+}
+
+// previousRuns holds the span contexts of the last runs of the entries.
+type previousRuns struct {
+	mu    sync.Mutex
+	spans map[cron.EntryID]trace.SpanContext
+}
+
+func newPreviousRuns() *previousRuns {
+	return &previousRuns{spans: make(map[cron.EntryID]trace.SpanContext)}
+}
+
+func (p *previousRuns) get(id cron.EntryID) (trace.SpanContext, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	sc, ok := p.spans[id]
+	return sc, ok
+}
+
+func (p *previousRuns) set(id cron.EntryID, sc trace.SpanContext) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.spans[id] = sc
+}
+
+func (p *previousRuns) delete(id cron.EntryID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.spans, id)
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"math/rand/v2"
 	"testing"
+	"time"
 
+	"github.com/flc1125/go-cron/crontest/v4/clock"
 	"github.com/flc1125/go-cron/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -78,6 +80,7 @@ func TestTracing(t *testing.T) {
 			assert.Contains(t, span.Attributes, attribute.Int("cron.job.id", int(entry.ID())))
 			assert.Contains(t, span.Attributes, attribute.String("cron.job.name", tt.name))
 			assert.Contains(t, span.Attributes, attribute.String("test.job", tt.name))
+			assert.Contains(t, span.Attributes, attribute.String("cron.job.trigger", "schedule"))
 			tt.extraTesting(t, span)
 		})
 	}
@@ -91,3 +94,116 @@ func TestTracing_NotJobWithName(t *testing.T) {
 	})).Run(ctx))
 	require.Len(t, imsb.GetSpans(), 0)
 }
+
+func TestTracing_EntryName(t *testing.T) {
+	defer imsb.Reset()
+
+	entry := cron.NewEntry(1, nil, cron.JobFunc(func(context.Context) error {
+		return nil
+	}), cron.WithEntryName("named"), cron.WithEntryMiddlewares(middleware))
+
+	require.NoError(t, entry.WrappedJob().Run(ctx))
+	require.Len(t, imsb.GetSpans(), 1)
+
+	span := imsb.GetSpans()[0]
+	assert.Equal(t, "cron named", span.Name)
+	assert.Contains(t, span.Attributes, attribute.String("cron.job.name", "named"))
+}
+
+func TestTracing_Times(t *testing.T) {
+	defer imsb.Reset()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clk := clock.New(start)
+	c := cron.New(
+		cron.WithClock(clk),
+		cron.WithLocation(time.UTC),
+		cron.WithLogger(cron.DiscardLogger),
+		cron.WithMiddleware(clk.Middleware(), middleware),
+	)
+	_, err := c.AddJob("@hourly", &mockJob{t: t, name: "times"})
+	require.NoError(t, err)
+
+	c.Start()
+	defer c.Stop()
+
+	clk.BlockUntil(1)
+	clk.Advance(time.Hour)
+	clk.Runs(1)
+
+	spans := imsb.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Contains(t, spans[0].Attributes, attribute.String("cron.job.prev.time", "2024-01-01T01:00:00Z"))
+	assert.Contains(t, spans[0].Attributes, attribute.String("cron.job.next.time", "2024-01-01T02:00:00Z"))
+
+	// Entries that have never been run have neither previous nor next times.
+	imsb.Reset()
+	unscheduled := cron.NewEntry(1, nil, &mockJob{t: t, name: "times"}, cron.WithEntryMiddlewares(middleware))
+	require.NoError(t, unscheduled.WrappedJob().Run(ctx))
+	require.Len(t, imsb.GetSpans(), 1)
+	for _, attr := range imsb.GetSpans()[0].Attributes {
+		assert.NotEqual(t, attribute.Key("cron.job.prev.time"), attr.Key)
+		assert.NotEqual(t, attribute.Key("cron.job.next.time"), attr.Key)
+	}
+}
+
+func TestTracing_CallerLink(t *testing.T) {
+	defer imsb.Reset()
+
+	parentCtx, parent := provider.Tracer("test").Start(ctx, "request")
+	parent.End()
+	imsb.Reset()
+
+	linked := New(WithTracerProvider(provider), WithCallerLink())
+	entry := cron.NewEntry(1, nil, &mockJob{t: t, name: "linked"},
+		cron.WithEntryCallerContext(parentCtx), cron.WithEntryMiddlewares(linked))
+	require.NoError(t, entry.WrappedJob().Run(ctx))
+
+	spans := imsb.GetSpans()
+	require.Len(t, spans, 1)
+	require.Len(t, spans[0].Links, 1)
+	assert.Equal(t, parent.SpanContext(), spans[0].Links[0].SpanContext)
+	assert.NotEqual(t, parent.SpanContext().TraceID(), spans[0].SpanContext.TraceID())
+
+	// Entries added without a caller context have no link.
+	imsb.Reset()
+	entry = cron.NewEntry(2, nil, &mockJob{t: t, name: "unlinked"}, cron.WithEntryMiddlewares(linked))
+	require.NoError(t, entry.WrappedJob().Run(ctx))
+	require.Len(t, imsb.GetSpans(), 1)
+	assert.Empty(t, imsb.GetSpans()[0].Links)
+}
+
+func TestTracing_PreviousRunLink(t *testing.T) {
+	defer imsb.Reset()
+
+	linked := New(WithTracerProvider(provider), WithPreviousRunLink())
+	entry := cron.NewEntry(1, nil, &mockJob{t: t, name: "linked"}, cron.WithEntryMiddlewares(linked))
+	other := cron.NewEntry(2, nil, &mockJob{t: t, name: "other"}, cron.WithEntryMiddlewares(linked))
+
+	require.NoError(t, entry.WrappedJob().Run(ctx))
+	require.NoError(t, other.WrappedJob().Run(ctx))
+	require.NoError(t, entry.WrappedJob().Run(ctx))
+
+	spans := imsb.GetSpans()
+	require.Len(t, spans, 3)
+	assert.Empty(t, spans[0].Links)
+	assert.Empty(t, spans[1].Links)
+	require.Len(t, spans[2].Links, 1)
+	assert.Equal(t, spans[0].SpanContext, spans[2].Links[0].SpanContext)
+}
+
+func TestTracing_ListenEntryRemoved(t *testing.T) {
+	defer imsb.Reset()
+
+	tracing := NewTracing(WithTracerProvider(provider), WithPreviousRunLink())
+	entry := cron.NewEntry(1, nil, &mockJob{t: t, name: "removed"}, cron.WithEntryMiddlewares(tracing.Middleware()))
+
+	require.NoError(t, entry.WrappedJob().Run(ctx))
+	tracing.Listen(cron.EntryRemoved{EntryID: entry.ID()})
+	assert.Empty(t, tracing.previous.spans)
+	require.NoError(t, entry.WrappedJob().Run(ctx))
+
+	spans := imsb.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Empty(t, spans[1].Links)
+}
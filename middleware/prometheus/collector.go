@@ -0,0 +1,58 @@
+package prometheus
+
+import (
+	"github.com/flc1125/go-cron/v4"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Collector collects the scheduler metrics of a Cron when it is scraped.
+type Collector struct {
+	cron      *cron.Cron
+	entries   *prometheus.Desc
+	running   *prometheus.Desc
+	queued    *prometheus.Desc
+	untilNext *prometheus.Desc
+}
+
+var _ prometheus.Collector = (*Collector)(nil)
+
+// NewCollector returns a collector for the scheduler metrics of the given Cron:
+// the number of entries, the number of running and queued jobs, and the
+// number of seconds until the next run, measured by the clock of the Cron. Only the namespace option applies, the
+// collector must be registered by the caller.
+func NewCollector(c *cron.Cron, opts ...Option) *Collector {
+	o := newOptions(opts...)
+	return &Collector{
+		cron: c,
+		entries: prometheus.NewDesc(prometheus.BuildFQName(o.namespace, "", "entries"),
+			"Number of entries.", nil, nil),
+		running: prometheus.NewDesc(prometheus.BuildFQName(o.namespace, "", "jobs_in_flight"),
+			"Number of running jobs.", nil, nil),
+		queued: prometheus.NewDesc(prometheus.BuildFQName(o.namespace, "", "jobs_queued"),
+			"Number of job runs waiting for a running job to return.", nil, nil),
+		untilNext: prometheus.NewDesc(prometheus.BuildFQName(o.namespace, "", "next_run_seconds"),
+			"Number of seconds until the next job run.", nil, nil),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.entries
+	ch <- c.running
+	ch <- c.queued
+	ch <- c.untilNext
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	entries := c.cron.Entries()
+	ch <- prometheus.MustNewConstMetric(c.entries, prometheus.GaugeValue, float64(len(entries)))
+	ch <- prometheus.MustNewConstMetric(c.running, prometheus.GaugeValue, float64(c.cron.RunningJobs()))
+	ch <- prometheus.MustNewConstMetric(c.queued, prometheus.GaugeValue, float64(c.cron.QueuedJobs()))
+
+	// The entries are ordered by next activation time, with zero times last.
+	if len(entries) > 0 && !entries[0].Next().IsZero() {
+		until := entries[0].Next().Sub(c.cron.Now()).Seconds()
+		ch <- prometheus.MustNewConstMetric(c.untilNext, prometheus.GaugeValue, max(until, 0))
+	}
+}
@@ -0,0 +1,87 @@
+package prometheus
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/flc1125/go-cron/crontest/v4/clock"
+	"github.com/flc1125/go-cron/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollector(t *testing.T) {
+	c := cron.New(cron.WithLogger(cron.DiscardLogger))
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(NewCollector(c))
+
+	// Before the Cron is started, the entries have no next run.
+	_, err := c.AddFunc("@every 1h", func(context.Context) error { return nil })
+	require.NoError(t, err)
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP cron_entries Number of entries.
+# TYPE cron_entries gauge
+cron_entries 1
+# HELP cron_jobs_in_flight Number of running jobs.
+# TYPE cron_jobs_in_flight gauge
+cron_jobs_in_flight 0
+# HELP cron_jobs_queued Number of job runs waiting for a running job to return.
+# TYPE cron_jobs_queued gauge
+cron_jobs_queued 0
+`)))
+
+	c.Start()
+	defer c.Stop()
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	id, err := c.AddFunc("@every 2h", func(context.Context) error {
+		close(started)
+		<-release
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, c.RunNow(id))
+	<-started
+	defer close(release)
+
+	assert.Equal(t, 2.0, gauge(t, registry, "cron_entries"))
+	assert.Equal(t, 1.0, gauge(t, registry, "cron_jobs_in_flight"))
+	assert.InDelta(t, time.Hour.Seconds(), gauge(t, registry, "cron_next_run_seconds"), 5)
+}
+
+// gauge returns the value of the gauge with the given name.
+func gauge(t *testing.T, registry *prometheus.Registry, name string) float64 {
+	t.Helper()
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() == name {
+			return family.GetMetric()[0].GetGauge().GetValue()
+		}
+	}
+	t.Fatalf("gauge %s not found", name)
+	return 0
+}
+
+func TestCollector_Clock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clk := clock.New(start)
+	c := cron.New(cron.WithClock(clk), cron.WithLocation(time.UTC), cron.WithLogger(cron.DiscardLogger))
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(NewCollector(c))
+
+	_, err := c.AddFunc("@every 1h", func(context.Context) error { return nil })
+	require.NoError(t, err)
+	c.Start()
+	defer c.Stop()
+
+	// The time until the next run follows the clock of the Cron.
+	clk.BlockUntil(1)
+	clk.Advance(15 * time.Minute)
+	assert.Equal(t, (45 * time.Minute).Seconds(), gauge(t, registry, "cron_next_run_seconds"))
+}
@@ -0,0 +1,30 @@
+module github.com/flc1125/go-cron/middleware/prometheus/v4
+
+go 1.23.0
+
+replace (
+	github.com/flc1125/go-cron/crontest/v4 => ../../crontest
+	github.com/flc1125/go-cron/v4 => ../../
+)
+
+require (
+	github.com/flc1125/go-cron/crontest/v4 v4.5.0
+	github.com/flc1125/go-cron/v4 v4.5.0
+	github.com/prometheus/client_golang v1.21.1
+	github.com/stretchr/testify v1.10.0
+)
+
+require (
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/kylelemons/godebug v1.1.0 // indirect
+	github.com/munnerz/goautoneg v0.0.0-20191010083416-a7dc8b61c822 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/prometheus/client_model v0.6.1 // indirect
+	github.com/prometheus/common v0.62.0 // indirect
+	github.com/prometheus/procfs v0.15.1 // indirect
+	golang.org/x/sys v0.28.0 // indirect
+	google.golang.org/protobuf v1.36.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,178 @@
+package prometheus
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/flc1125/go-cron/v4"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	labelEntry  = "entry"
+	labelName   = "name"
+	labelReason = "reason"
+)
+
+type options struct {
+	registerer prometheus.Registerer
+	namespace  string
+	buckets    []float64
+}
+
+type Option func(*options)
+
+// WithRegisterer sets the registerer of the metrics.
+// The default is prometheus.DefaultRegisterer.
+func WithRegisterer(registerer prometheus.Registerer) Option {
+	return func(o *options) {
+		o.registerer = registerer
+	}
+}
+
+// WithNamespace sets the namespace of the metrics. The default is "cron".
+func WithNamespace(namespace string) Option {
+	return func(o *options) {
+		o.namespace = namespace
+	}
+}
+
+// WithBuckets sets the buckets of the job duration histogram, in seconds.
+// The default is prometheus.DefBuckets.
+func WithBuckets(buckets []float64) Option {
+	return func(o *options) {
+		o.buckets = buckets
+	}
+}
+
+func newOptions(opts ...Option) *options {
+	opt := &options{
+		registerer: prometheus.DefaultRegisterer,
+		namespace:  "cron",
+		buckets:    prometheus.DefBuckets,
+	}
+	for _, o := range opts {
+		o(opt)
+	}
+	return opt
+}
+
+// JobWithName is a job that provides its own name for the metrics. Jobs that
+// don't implement it are labelled with the name of their entry, see
+// cron.WithEntryName.
+type JobWithName interface {
+	cron.Job
+
+	// Name returns the name of the job.
+	Name() string
+}
+
+// Metrics records the runs of the jobs, labelled by entry id and job name.
+type Metrics struct {
+	runs        *prometheus.CounterVec
+	errors      *prometheus.CounterVec
+	skipped     *prometheus.CounterVec
+	duration    *prometheus.HistogramVec
+	lastSuccess *prometheus.GaugeVec
+}
+
+// NewMetrics creates the job metrics and registers them with the configured
+// registerer. It panics if they are already registered.
+func NewMetrics(opts ...Option) *Metrics {
+	o := newOptions(opts...)
+	labels := []string{labelEntry, labelName}
+	m := &Metrics{
+		runs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: o.namespace,
+			Name:      "job_runs_total",
+			Help:      "Number of job runs.",
+		}, labels),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: o.namespace,
+			Name:      "job_errors_total",
+			Help:      "Number of job runs that returned an error.",
+		}, labels),
+		skipped: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: o.namespace,
+			Name:      "job_skipped_total",
+			Help:      "Number of job runs that have been skipped.",
+		}, append(labels, labelReason)),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: o.namespace,
+			Name:      "job_duration_seconds",
+			Help:      "Duration of job runs in seconds.",
+			Buckets:   o.buckets,
+		}, labels),
+		lastSuccess: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: o.namespace,
+			Name:      "job_last_success_timestamp_seconds",
+			Help:      "Unix time of the last successful job run.",
+		}, labels),
+	}
+	o.registerer.MustRegister(m.runs, m.errors, m.skipped, m.duration, m.lastSuccess)
+	return m
+}
+
+// New returns a middleware recording the job metrics, see NewMetrics.
+func New(opts ...Option) cron.Middleware {
+	return NewMetrics(opts...).Middleware()
+}
+
+// Middleware returns a middleware recording the runs of the jobs.
+// Jobs that are not run by a Cron are not recorded.
+func (m *Metrics) Middleware() cron.Middleware {
+	return func(original cron.Job) cron.Job {
+		return cron.JobFunc(func(ctx context.Context) error {
+			entry, ok := cron.EntryFromContext(ctx)
+			if !ok {
+				return original.Run(ctx)
+			}
+
+			labels := entryLabels(entry)
+			start := time.Now()
+			err := original.Run(ctx)
+			end := time.Now()
+
+			m.runs.With(labels).Inc()
+			m.duration.With(labels).Observe(end.Sub(start).Seconds())
+			if err != nil {
+				m.errors.With(labels).Inc()
+			} else {
+				m.lastSuccess.With(labels).Set(float64(end.UnixNano()) / 1e9)
+			}
+
+			return err
+		})
+	}
+}
+
+// Listen records the skipped runs of the jobs, and deletes the series of the
+// removed entries. It is a cron.EventListener, see cron.WithEventListener.
+func (m *Metrics) Listen(event cron.Event) {
+	switch e := event.(type) {
+	case cron.JobSkipped:
+		labels := entryLabels(e.Entry)
+		labels[labelReason] = e.Reason
+		m.skipped.With(labels).Inc()
+	case cron.EntryRemoved:
+		labels := prometheus.Labels{labelEntry: strconv.Itoa(int(e.EntryID))}
+		m.runs.DeletePartialMatch(labels)
+		m.errors.DeletePartialMatch(labels)
+		m.skipped.DeletePartialMatch(labels)
+		m.duration.DeletePartialMatch(labels)
+		m.lastSuccess.DeletePartialMatch(labels)
+	}
+}
+
+// entryLabels returns the labels of the given entry.
+func entryLabels(entry *cron.Entry) prometheus.Labels {
+	name := entry.Name()
+	if job, ok := any(entry.Job()).(JobWithName); ok {
+		name = job.Name()
+	}
+	return prometheus.Labels{
+		labelEntry: strconv.Itoa(int(entry.ID())),
+		labelName:  name,
+	}
+}
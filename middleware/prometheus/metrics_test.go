@@ -0,0 +1,104 @@
+package prometheus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flc1125/go-cron/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type namedJob struct {
+	err error
+}
+
+func (j namedJob) Name() string { return "named:job" }
+
+func (j namedJob) Run(context.Context) error { return j.err }
+
+func TestMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	metrics := NewMetrics(WithRegisterer(registry), WithNamespace("test"))
+	middleware := metrics.Middleware()
+
+	run := func(entry *cron.Entry) error {
+		return middleware(entry.Job()).Run(cron.WithEntryContext(context.Background(), entry))
+	}
+
+	named := cron.NewEntry(1, cron.Every(time.Minute), cron.JobFunc(func(context.Context) error {
+		return nil
+	}), cron.WithEntryName("backup"))
+	failing := cron.NewEntry(2, cron.Every(time.Minute), namedJob{err: assert.AnError})
+
+	before := time.Now()
+	require.NoError(t, run(named))
+	require.NoError(t, run(named))
+	require.Error(t, run(failing))
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(metrics.runs.WithLabelValues("1", "backup")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.runs.WithLabelValues("2", "named:job")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(metrics.errors.WithLabelValues("1", "backup")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.errors.WithLabelValues("2", "named:job")))
+	assert.InDelta(t, float64(before.Unix()), testutil.ToFloat64(metrics.lastSuccess.WithLabelValues("1", "backup")), 1)
+	assert.Equal(t, 2, testutil.CollectAndCount(metrics.duration))
+	assert.Equal(t, 1, testutil.CollectAndCount(metrics.lastSuccess))
+
+	metrics.Listen(cron.JobSkipped{Entry: named, Reason: cron.SkipReasonMisfire})
+	metrics.Listen(cron.JobStarted{Entry: named})
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.skipped.WithLabelValues("1", "backup", cron.SkipReasonMisfire)))
+	assert.Equal(t, 1, testutil.CollectAndCount(metrics.skipped))
+
+	count, err := testutil.GatherAndCount(registry, "test_job_runs_total", "test_job_duration_seconds")
+	require.NoError(t, err)
+	assert.Equal(t, 4, count)
+
+	// The series of the removed entries are deleted.
+	metrics.Listen(cron.EntryRemoved{EntryID: named.ID()})
+	assert.Equal(t, 1, testutil.CollectAndCount(metrics.runs))
+	assert.Equal(t, 0, testutil.CollectAndCount(metrics.skipped))
+	assert.Equal(t, 1, testutil.CollectAndCount(metrics.duration))
+	assert.Equal(t, 0, testutil.CollectAndCount(metrics.lastSuccess))
+
+	assert.Panics(t, func() { NewMetrics(WithRegisterer(registry), WithNamespace("test")) })
+}
+
+func TestMetrics_WithoutEntry(t *testing.T) {
+	metrics := NewMetrics(WithRegisterer(prometheus.NewRegistry()))
+	assert.Equal(t, assert.AnError, metrics.Middleware()(namedJob{err: assert.AnError}).Run(context.Background()))
+	assert.Equal(t, 0, testutil.CollectAndCount(metrics.runs))
+}
+
+func TestNew(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	c := cron.New(
+		cron.WithSeconds(),
+		cron.WithLogger(cron.DiscardLogger),
+		cron.WithMiddleware(New(WithRegisterer(registry))),
+	)
+	done := make(chan struct{}, 1)
+	_, err := c.AddFunc("* * * * * ?", func(context.Context) error {
+		select {
+		case done <- struct{}{}:
+		default:
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	c.Start()
+	defer c.Stop()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the job to run")
+	}
+	assert.Eventually(t, func() bool {
+		count, err := testutil.GatherAndCount(registry, "cron_job_runs_total")
+		return err == nil && count == 1
+	}, time.Second, 10*time.Millisecond)
+}
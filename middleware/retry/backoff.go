@@ -0,0 +1,32 @@
+package retry
+
+import "time"
+
+// Backoff returns the delay to wait before retrying a job whose given attempt,
+// starting at 1, has failed.
+type Backoff func(attempt int) time.Duration
+
+// Exponential doubles the delay after each attempt, starting at base, up to maxDelay.
+func Exponential(base, maxDelay time.Duration) Backoff {
+	return func(attempt int) time.Duration {
+		d := base
+		for i := 1; i < attempt && d < maxDelay; i++ {
+			d *= 2
+		}
+		return min(d, maxDelay)
+	}
+}
+
+// Linear increases the delay by step after each attempt, up to maxDelay.
+func Linear(step, maxDelay time.Duration) Backoff {
+	return func(attempt int) time.Duration {
+		return min(step*time.Duration(attempt), maxDelay)
+	}
+}
+
+// Constant always waits the given delay.
+func Constant(delay time.Duration) Backoff {
+	return func(int) time.Duration {
+		return delay
+	}
+}
@@ -0,0 +1,34 @@
+package retry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoff(t *testing.T) {
+	exponential := Exponential(time.Second, 10*time.Second)
+	assert.Equal(t, time.Second, exponential(1))
+	assert.Equal(t, 2*time.Second, exponential(2))
+	assert.Equal(t, 8*time.Second, exponential(4))
+	assert.Equal(t, 10*time.Second, exponential(5))
+	assert.Equal(t, 10*time.Second, exponential(100))
+
+	linear := Linear(time.Second, 3*time.Second)
+	assert.Equal(t, time.Second, linear(1))
+	assert.Equal(t, 2*time.Second, linear(2))
+	assert.Equal(t, 3*time.Second, linear(5))
+
+	constant := Constant(time.Second)
+	assert.Equal(t, time.Second, constant(1))
+	assert.Equal(t, time.Second, constant(10))
+}
+
+func TestJitter(t *testing.T) {
+	o := newOptions(WithBackoff(Constant(time.Second)), WithJitter(0.1))
+	for i := 0; i < 100; i++ {
+		assert.InDelta(t, time.Second, o.delay(1), float64(100*time.Millisecond))
+	}
+	assert.Equal(t, time.Second, newOptions(WithBackoff(Constant(time.Second))).delay(1))
+}
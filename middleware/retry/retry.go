@@ -0,0 +1,137 @@
+package retry
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"github.com/flc1125/go-cron/v4"
+)
+
+type options struct {
+	logger      cron.Logger
+	maxAttempts int
+	backoff     Backoff
+	jitter      float64
+	retryable   func(error) bool
+}
+
+type Option func(*options)
+
+func newOptions(opts ...Option) options {
+	opt := options{
+		logger:      cron.DefaultLogger,
+		maxAttempts: 3,
+		backoff:     Exponential(time.Second, time.Minute),
+		retryable:   func(error) bool { return true },
+	}
+	for _, o := range opts {
+		o(&opt)
+	}
+	return opt
+}
+
+func WithLogger(logger cron.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithMaxAttempts sets the maximum number of attempts of a run, including the
+// first one. The default is 3.
+func WithMaxAttempts(n int) Option {
+	return func(o *options) {
+		o.maxAttempts = n
+	}
+}
+
+// WithBackoff sets the delay between attempts. The default is an exponential
+// backoff from one second up to one minute.
+func WithBackoff(backoff Backoff) Option {
+	return func(o *options) {
+		o.backoff = backoff
+	}
+}
+
+// WithJitter randomizes the delay between attempts by up to the given fraction
+// of it, e.g. 0.1 for a delay within 10% of the one of the backoff.
+func WithJitter(fraction float64) Option {
+	return func(o *options) {
+		o.jitter = fraction
+	}
+}
+
+// WithRetryable sets the predicate deciding whether the error of an attempt
+// is retried. By default, every error is retried.
+func WithRetryable(retryable func(error) bool) Option {
+	return func(o *options) {
+		o.retryable = retryable
+	}
+}
+
+// delay returns the delay to wait after the given failed attempt.
+func (o options) delay(attempt int) time.Duration {
+	d := o.backoff(attempt)
+	if o.jitter > 0 {
+		d += time.Duration((rand.Float64()*2 - 1) * o.jitter * float64(d))
+	}
+	return max(d, 0)
+}
+
+// deadline returns the next activation of the entry run from ctx after the
+// given time, or the zero time if there is none.
+func deadline(ctx context.Context, start time.Time) time.Time {
+	entry, ok := cron.EntryFromContext(ctx)
+	if !ok || entry.Schedule() == nil {
+		return time.Time{}
+	}
+	return entry.Schedule().Next(start)
+}
+
+// New returns a retry middleware.
+// It runs a failed job again after a backoff delay, until it succeeds, returns
+// an error that is not retryable or runs out of attempts. Retries never start
+// after the next activation of the entry, in which case the error of the last
+// attempt is returned.
+func New(opts ...Option) cron.Middleware {
+	o := newOptions(opts...)
+	return func(job cron.Job) cron.Job {
+		return cron.JobFunc(func(ctx context.Context) error {
+			next := deadline(ctx, time.Now())
+			for attempt := 1; ; attempt++ {
+				err := job.Run(withAttempt(ctx, attempt))
+				if err == nil || attempt >= o.maxAttempts || !o.retryable(err) {
+					return err
+				}
+
+				delay := o.delay(attempt)
+				if !next.IsZero() && !time.Now().Add(delay).Before(next) {
+					o.logger.Info("retry abandoned, next activation is due", "attempt", attempt, "next", next)
+					return err
+				}
+				o.logger.Info("retry", "attempt", attempt, "delay", delay, "error", err)
+
+				timer := time.NewTimer(delay)
+				select {
+				case <-timer.C:
+				case <-ctx.Done():
+					timer.Stop()
+					return err
+				}
+			}
+		})
+	}
+}
+
+type attemptContextKey struct{}
+
+func withAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, attemptContextKey{}, attempt)
+}
+
+// AttemptFromContext returns the number of the attempt being run, starting at
+// 1, or 0 if the job is not run by the retry middleware.
+func AttemptFromContext(ctx context.Context) int {
+	attempt, _ := ctx.Value(attemptContextKey{}).(int)
+	return attempt
+}
@@ -0,0 +1,123 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/flc1125/go-cron/crontest/v4/logger"
+	"github.com/flc1125/go-cron/v4"
+)
+
+var errJob = errors.New("job failed")
+
+// failingJob fails until the given attempt, recording the attempts it was run with.
+func failingJob(succeedAt int, attempts *[]int) cron.Job {
+	return cron.JobFunc(func(ctx context.Context) error {
+		attempt := AttemptFromContext(ctx)
+		*attempts = append(*attempts, attempt)
+		if attempt < succeedAt {
+			return errJob
+		}
+		return nil
+	})
+}
+
+func TestRetry(t *testing.T) {
+	buf := logger.NewBuffer()
+	retry := New(
+		WithBackoff(Constant(time.Millisecond)),
+		WithLogger(logger.NewBufferLogger(buf)),
+	)
+
+	var attempts []int
+	assert.NoError(t, retry(failingJob(3, &attempts)).Run(context.Background()))
+	assert.Equal(t, []int{1, 2, 3}, attempts)
+	assert.True(t, strings.Contains(buf.String(), "retry"))
+}
+
+func TestRetry_MaxAttempts(t *testing.T) {
+	var attempts []int
+	err := New(
+		WithMaxAttempts(2),
+		WithBackoff(Constant(time.Millisecond)),
+	)(failingJob(3, &attempts)).Run(context.Background())
+
+	assert.Equal(t, errJob, err)
+	assert.Equal(t, []int{1, 2}, attempts)
+}
+
+func TestRetry_Retryable(t *testing.T) {
+	var attempts []int
+	err := New(
+		WithBackoff(Constant(time.Millisecond)),
+		WithRetryable(func(err error) bool { return !errors.Is(err, errJob) }),
+	)(failingJob(3, &attempts)).Run(context.Background())
+
+	assert.Equal(t, errJob, err)
+	assert.Equal(t, []int{1}, attempts)
+}
+
+func TestRetry_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var attempts []int
+	err := New(WithBackoff(Constant(time.Hour)))(cron.JobFunc(func(ctx context.Context) error {
+		attempts = append(attempts, AttemptFromContext(ctx))
+		cancel()
+		return errJob
+	})).Run(ctx)
+
+	assert.Equal(t, errJob, err)
+	assert.Equal(t, []int{1}, attempts)
+}
+
+func TestRetry_NextActivation(t *testing.T) {
+	buf := logger.NewBuffer()
+	retry := New(
+		WithBackoff(Constant(time.Hour)),
+		WithLogger(logger.NewBufferLogger(buf)),
+	)
+
+	var attempts []int
+	entry := cron.NewEntry(1, cron.Every(time.Minute), nil)
+	err := retry(failingJob(2, &attempts)).Run(cron.WithEntryContext(context.Background(), entry))
+
+	assert.Equal(t, errJob, err)
+	assert.Equal(t, []int{1}, attempts)
+	assert.True(t, strings.Contains(buf.String(), "next activation is due"))
+}
+
+func TestAttemptFromContext(t *testing.T) {
+	assert.Equal(t, 0, AttemptFromContext(context.Background()))
+	assert.Equal(t, 2, AttemptFromContext(withAttempt(context.Background(), 2)))
+}
+
+func TestRetry_Cron(t *testing.T) {
+	c := cron.New(cron.WithSeconds(), cron.WithLogger(cron.DiscardLogger))
+	attempts := make(chan int, 10)
+	_, err := c.AddFunc("* * * * * ?", func(ctx context.Context) error {
+		attempt := AttemptFromContext(ctx)
+		attempts <- attempt
+		if attempt < 2 {
+			return errJob
+		}
+		return nil
+	}, New(WithBackoff(Constant(10*time.Millisecond))))
+	assert.NoError(t, err)
+
+	c.Start()
+	defer c.Stop()
+
+	for want := 1; want <= 2; want++ {
+		select {
+		case attempt := <-attempts:
+			assert.Equal(t, want, attempt)
+		case <-time.After(2 * time.Second):
+			t.Fatal("expected the job to be retried")
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package timeout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/flc1125/go-cron/v4"
+)
+
+// Error is returned by a job run that has exceeded its deadline.
+type Error struct {
+	// Timeout is the duration the run was allowed to take.
+	Timeout time.Duration
+
+	// Abandoned is true if the job ignored the cancellation of its context
+	// and has been left running, see WithAbandon.
+	Abandoned bool
+
+	// Err is the error returned by the job, or nil if it has been abandoned.
+	Err error
+}
+
+func (e *Error) Error() string {
+	if e.Abandoned {
+		return fmt.Sprintf("cron: job timed out after %s and was abandoned", e.Timeout)
+	}
+	if e.Err != nil {
+		return fmt.Sprintf("cron: job timed out after %s: %v", e.Timeout, e.Err)
+	}
+	return fmt.Sprintf("cron: job timed out after %s", e.Timeout)
+}
+
+// Unwrap returns context.DeadlineExceeded and the error returned by the job.
+func (e *Error) Unwrap() []error {
+	if e.Err != nil {
+		return []error{context.DeadlineExceeded, e.Err}
+	}
+	return []error{context.DeadlineExceeded}
+}
+
+type options struct {
+	logger   cron.Logger
+	timeout  time.Duration
+	fraction float64
+	abandon  bool
+	grace    time.Duration
+}
+
+type Option func(*options)
+
+func newOptions(opts ...Option) options {
+	opt := options{
+		logger: cron.DefaultLogger,
+	}
+	for _, o := range opts {
+		o(&opt)
+	}
+	return opt
+}
+
+func WithLogger(logger cron.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithTimeout bounds every run to the given duration.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithFraction bounds every run to the given fraction of the interval between
+// the activation of the run and the next activation of its entry, as scheduled
+// by the Cron, e.g. 0.5 for half of it. Runs of jobs that are not run by a Cron
+// are not bounded by it.
+func WithFraction(fraction float64) Option {
+	return func(o *options) {
+		o.fraction = fraction
+	}
+}
+
+// WithAbandon stops waiting for jobs that are still running the given grace
+// period after their deadline, i.e. that ignore the cancellation of their
+// context. The run then returns an *Error right away and the job is left
+// running in the background.
+func WithAbandon(grace time.Duration) Option {
+	return func(o *options) {
+		o.abandon = true
+		o.grace = grace
+	}
+}
+
+// timeoutFor returns the duration the run of the job may take, or zero if it
+// is not bounded. If both a timeout and a fraction are set, the shorter
+// duration applies.
+func (o options) timeoutFor(ctx context.Context) time.Duration {
+	timeout := o.timeout
+	if o.fraction <= 0 {
+		return timeout
+	}
+	entry, ok := cron.EntryFromContext(ctx)
+	if !ok {
+		return timeout
+	}
+	next, prev := entry.Next(), entry.Prev()
+	if next.IsZero() {
+		return timeout
+	}
+	if schedule, ok := entry.Schedule().(cron.PrevSchedule); ok && prev.IsZero() {
+		prev = schedule.Prev(next)
+	}
+	if prev.IsZero() {
+		return timeout
+	}
+	if d := time.Duration(float64(next.Sub(prev)) * o.fraction); d > 0 && (timeout <= 0 || d < timeout) {
+		return d
+	}
+	return timeout
+}
+
+// New returns a timeout middleware.
+// It cancels the context of a job run once the run has exceeded its timeout,
+// see WithTimeout and WithFraction, and then returns an *Error.
+func New(opts ...Option) cron.Middleware {
+	o := newOptions(opts...)
+	return func(job cron.Job) cron.Job {
+		return cron.JobFunc(func(ctx context.Context) error {
+			timeout := o.timeoutFor(ctx)
+			if timeout <= 0 {
+				return job.Run(ctx)
+			}
+
+			runCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			if !o.abandon {
+				return o.result(ctx, runCtx, timeout, job.Run(runCtx))
+			}
+
+			done := make(chan error, 1)
+			go func() {
+				done <- job.Run(runCtx)
+			}()
+
+			select {
+			case err := <-done:
+				return o.result(ctx, runCtx, timeout, err)
+			case <-runCtx.Done():
+			}
+
+			grace := time.NewTimer(o.grace)
+			defer grace.Stop()
+			select {
+			case err := <-done:
+				return o.result(ctx, runCtx, timeout, err)
+			case <-grace.C:
+				err := &Error{Timeout: timeout, Abandoned: true}
+				o.logger.Error(err, "job ignored cancellation, abandoned", "timeout", timeout, "grace", o.grace)
+				return err
+			}
+		})
+	}
+}
+
+// result returns the error of a run, wrapped in an *Error if the run has
+// exceeded its own deadline rather than the one of its parent context.
+func (o options) result(parent, ctx context.Context, timeout time.Duration, err error) error {
+	if parent.Err() != nil || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+	o.logger.Info("job timed out", "timeout", timeout)
+	return &Error{Timeout: timeout, Err: err}
+}
@@ -0,0 +1,190 @@
+package timeout
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/flc1125/go-cron/crontest/v4/clock"
+	"github.com/flc1125/go-cron/crontest/v4/logger"
+	"github.com/flc1125/go-cron/v4"
+)
+
+var errJob = errors.New("job failed")
+
+func TestTimeout(t *testing.T) {
+	buf := logger.NewBuffer()
+	timeout := New(WithTimeout(10*time.Millisecond), WithLogger(logger.NewBufferLogger(buf)))
+
+	err := timeout(cron.JobFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})).Run(context.Background())
+
+	var timeoutErr *Error
+	require.ErrorAs(t, err, &timeoutErr)
+	assert.Equal(t, 10*time.Millisecond, timeoutErr.Timeout)
+	assert.False(t, timeoutErr.Abandoned)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, "cron: job timed out after 10ms: context deadline exceeded", err.Error())
+	assert.True(t, strings.Contains(buf.String(), "job timed out"))
+}
+
+func TestTimeout_NotExceeded(t *testing.T) {
+	timeout := New(WithTimeout(time.Second))
+
+	assert.NoError(t, timeout(cron.JobFunc(func(ctx context.Context) error {
+		_, ok := ctx.Deadline()
+		assert.True(t, ok)
+		return nil
+	})).Run(context.Background()))
+
+	assert.Equal(t, errJob, timeout(cron.JobFunc(func(context.Context) error {
+		return errJob
+	})).Run(context.Background()))
+}
+
+func TestTimeout_ParentCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := New(WithTimeout(time.Second))(cron.JobFunc(func(ctx context.Context) error {
+		return ctx.Err()
+	})).Run(ctx)
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestTimeout_Unbounded(t *testing.T) {
+	assert.NoError(t, New()(cron.JobFunc(func(ctx context.Context) error {
+		_, ok := ctx.Deadline()
+		assert.False(t, ok)
+		return nil
+	})).Run(context.Background()))
+
+	// Without an entry, the fraction does not apply.
+	assert.NoError(t, New(WithFraction(0.5))(cron.JobFunc(func(ctx context.Context) error {
+		_, ok := ctx.Deadline()
+		assert.False(t, ok)
+		return nil
+	})).Run(context.Background()))
+}
+
+func TestTimeout_Fraction(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	daily, err := cron.ParseStandard("@daily")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		schedule cron.Schedule
+		want     time.Duration
+	}{
+		{"fraction", []Option{WithFraction(0.5)}, cron.Every(time.Hour), 30 * time.Minute},
+		{"shorter timeout", []Option{WithFraction(0.5), WithTimeout(time.Minute)}, cron.Every(time.Hour), time.Minute},
+		{"shorter fraction", []Option{WithFraction(0.5), WithTimeout(time.Hour)}, cron.Every(time.Hour), 30 * time.Minute},
+		{"spec", []Option{WithFraction(0.5)}, daily, 12 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clk := clock.New(start)
+			c := cron.New(cron.WithClock(clk), cron.WithLocation(time.UTC), cron.WithLogger(cron.DiscardLogger))
+			got := make(chan time.Duration, 1)
+			c.Schedule(tt.schedule, cron.JobFunc(func(ctx context.Context) error {
+				got <- newOptions(tt.opts...).timeoutFor(ctx)
+				return nil
+			}))
+
+			c.Start()
+			defer c.Stop()
+			clk.BlockUntil(1)
+			clk.Advance(tt.schedule.Next(start).Sub(start))
+			assert.Equal(t, tt.want, <-got)
+		})
+	}
+
+	t.Run("zero next", func(t *testing.T) {
+		entry := cron.NewEntry(1, zeroSchedule{}, nil)
+		ctx := cron.WithEntryContext(context.Background(), entry)
+		assert.Equal(t, time.Minute, newOptions(WithFraction(0.5), WithTimeout(time.Minute)).timeoutFor(ctx))
+	})
+}
+
+type zeroSchedule struct{}
+
+func (zeroSchedule) Next(time.Time) time.Time { return time.Time{} }
+
+func TestTimeout_Abandon(t *testing.T) {
+	buf := logger.NewBuffer()
+	timeout := New(
+		WithTimeout(10*time.Millisecond),
+		WithAbandon(10*time.Millisecond),
+		WithLogger(logger.NewBufferLogger(buf)),
+	)
+
+	release := make(chan struct{})
+	defer close(release)
+	err := timeout(cron.JobFunc(func(context.Context) error {
+		<-release
+		return nil
+	})).Run(context.Background())
+
+	var timeoutErr *Error
+	require.ErrorAs(t, err, &timeoutErr)
+	assert.True(t, timeoutErr.Abandoned)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, "cron: job timed out after 10ms and was abandoned", err.Error())
+	assert.True(t, strings.Contains(buf.String(), "abandoned"))
+}
+
+func TestTimeout_AbandonGrace(t *testing.T) {
+	timeout := New(WithTimeout(10*time.Millisecond), WithAbandon(time.Second))
+
+	err := timeout(cron.JobFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		return errJob
+	})).Run(context.Background())
+
+	var timeoutErr *Error
+	require.ErrorAs(t, err, &timeoutErr)
+	assert.False(t, timeoutErr.Abandoned)
+	assert.ErrorIs(t, err, errJob)
+
+	assert.NoError(t, timeout(cron.JobFunc(func(context.Context) error {
+		return nil
+	})).Run(context.Background()))
+}
+
+func TestTimeout_Cron(t *testing.T) {
+	c := cron.New(cron.WithSeconds(), cron.WithLogger(cron.DiscardLogger))
+	errs := make(chan error, 1)
+	_, err := c.AddFunc("* * * * * ?", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}, func(job cron.Job) cron.Job {
+		return cron.JobFunc(func(ctx context.Context) error {
+			err := job.Run(ctx)
+			errs <- err
+			return err
+		})
+	}, New(WithFraction(0.01)))
+	require.NoError(t, err)
+
+	c.Start()
+	defer c.Stop()
+
+	select {
+	case err := <-errs:
+		var timeoutErr *Error
+		require.ErrorAs(t, err, &timeoutErr)
+		assert.InDelta(t, 10*time.Millisecond, timeoutErr.Timeout, float64(time.Millisecond))
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the job to time out")
+	}
+}
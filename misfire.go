@@ -0,0 +1,129 @@
+package cron
+
+import (
+	"slices"
+	"time"
+)
+
+// maxDueActivations bounds the number of activations of an entry that are
+// given to its MisfirePolicy when the scheduler wakes up late.
+const maxDueActivations = 1000
+
+// maxDueWalk bounds the number of activations walked through to count the due
+// activations of the schedules that can't be counted otherwise, see
+// dueActivations.
+const maxDueWalk = 100 * maxDueActivations
+
+// MisfirePolicy decides which activations of an entry are run when the
+// scheduler wakes up after one or more of them have passed, e.g. because the
+// process was suspended or the machine was asleep.
+//
+// It is given the most recent activations that are due, oldest first, at most
+// maxDueActivations of them, and returns the ones to run. All the due
+// activations that are not returned, including the older ones it has not been
+// given, are reported as missed, see MissedRunsFromContext. The missed
+// activations are counted exactly for ConstantDelaySchedule only; for other
+// schedules, the count is a lower bound once they are too many to walk through.
+type MisfirePolicy func(due []time.Time, now time.Time) []time.Time
+
+// MisfireFireOnce runs the entry once for all its due activations.
+// It is the default policy.
+func MisfireFireOnce() MisfirePolicy {
+	return func(due []time.Time, _ time.Time) []time.Time {
+		return due[len(due)-1:]
+	}
+}
+
+// MisfireFireAll runs the entry once for each of its due activations, up to
+// the given limit of the most recent ones.
+func MisfireFireAll(limit int) MisfirePolicy {
+	if limit < 1 {
+		limit = 1
+	}
+	return func(due []time.Time, _ time.Time) []time.Time {
+		if len(due) > limit {
+			return due[len(due)-limit:]
+		}
+		return due
+	}
+}
+
+// MisfireSkip does not run the entry if any of its activations has been
+// missed, that is if more than one activation is due.
+func MisfireSkip() MisfirePolicy {
+	return func(due []time.Time, _ time.Time) []time.Time {
+		if len(due) > 1 {
+			return nil
+		}
+		return due
+	}
+}
+
+// MisfireGrace runs the entry once if its most recent due activation is no
+// older than the given grace period, and skips it otherwise.
+func MisfireGrace(grace time.Duration) MisfirePolicy {
+	return func(due []time.Time, now time.Time) []time.Time {
+		if latest := due[len(due)-1]; now.Sub(latest) <= grace {
+			return []time.Time{latest}
+		}
+		return nil
+	}
+}
+
+// dueActivations returns the most recent activations of the schedule from next
+// up to now, at most maxDueActivations of them, and the number of all of them.
+//
+// The activations of a ConstantDelaySchedule are computed. Those of a
+// PrevSchedule are walked backwards from now, and the number is a lower bound
+// when there are more than maxDueActivations of them. Those of other schedules
+// are walked forwards from next, at most maxDueWalk of them, and the number is
+// a lower bound when the walk is cut short.
+func dueActivations(schedule Schedule, next, now time.Time) ([]time.Time, int) {
+	switch s := schedule.(type) {
+	case ConstantDelaySchedule:
+		if s.Delay <= 0 {
+			break
+		}
+		count := int(now.Sub(next)/s.Delay) + 1
+		due := make([]time.Time, min(count, maxDueActivations))
+		last := next.Add(time.Duration(count-1) * s.Delay)
+		for i := range due {
+			due[i] = last.Add(-time.Duration(len(due)-1-i) * s.Delay)
+		}
+		return due, count
+
+	case PrevSchedule:
+		var due []time.Time
+		for t := s.Prev(now.Add(time.Nanosecond)); len(due) < maxDueActivations; t = s.Prev(t) {
+			if t.IsZero() || t.Before(next) {
+				break
+			}
+			due = append(due, t)
+		}
+		if len(due) == 0 {
+			return []time.Time{next}, 1
+		}
+		slices.Reverse(due)
+		count := len(due)
+		if due[0].After(next) {
+			count++
+		}
+		return due, count
+	}
+
+	due, count := []time.Time{next}, 1
+	for t := range Between(schedule, next, now) {
+		if count == maxDueWalk {
+			break
+		}
+		count++
+		due = append(due, t)
+		if len(due) == 2*maxDueActivations {
+			due = append(due[:0], due[maxDueActivations:]...)
+		}
+	}
+	if len(due) > maxDueActivations {
+		due = due[len(due)-maxDueActivations:]
+	}
+	return due, count
+}
@@ -0,0 +1,228 @@
+package cron
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// manualClock is a Clock whose timers are handed over to the test, which
+// decides when they fire and with which time.
+type manualClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers chan chan time.Time
+}
+
+func newManualClock(now time.Time) *manualClock {
+	return &manualClock{now: now, timers: make(chan chan time.Time, 10)}
+}
+
+func (m *manualClock) Now() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.now
+}
+
+func (m *manualClock) NewTimer(time.Duration) Timer {
+	c := make(chan time.Time, 1)
+	m.timers <- c
+	return manualTimer(c)
+}
+
+// fire sets the time and fires the last armed timer, then waits for the
+// scheduler to arm the next one.
+func (m *manualClock) fire(now time.Time) {
+	timer := <-m.timers
+	for len(m.timers) > 0 {
+		timer = <-m.timers
+	}
+
+	m.mu.Lock()
+	m.now = now
+	m.mu.Unlock()
+	timer <- now
+	m.timers <- <-m.timers
+}
+
+type manualTimer chan time.Time
+
+func (t manualTimer) C() <-chan time.Time { return t }
+func (t manualTimer) Stop() bool          { return true }
+
+func TestMisfirePolicies(t *testing.T) {
+	var (
+		base = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		due  = []time.Time{base, base.Add(time.Hour), base.Add(2 * time.Hour)}
+		now  = base.Add(2*time.Hour + 30*time.Minute)
+	)
+
+	assert.Equal(t, due[2:], MisfireFireOnce()(due, now))
+	assert.Equal(t, due, MisfireFireAll(5)(due, now))
+	assert.Equal(t, due[1:], MisfireFireAll(2)(due, now))
+	assert.Equal(t, due[2:], MisfireFireAll(0)(due, now))
+	assert.Empty(t, MisfireSkip()(due, now))
+	assert.Equal(t, due[:1], MisfireSkip()(due[:1], now))
+	assert.Equal(t, due[2:], MisfireGrace(time.Hour)(due, now))
+	assert.Empty(t, MisfireGrace(time.Minute)(due, now))
+}
+
+func TestDueActivations(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	schedule := Every(time.Hour)
+
+	due, count := dueActivations(schedule, base, base.Add(time.Minute))
+	assert.Equal(t, []time.Time{base}, due)
+	assert.Equal(t, 1, count)
+
+	due, count = dueActivations(schedule, base, base.Add(2*time.Hour+time.Minute))
+	assert.Equal(t, []time.Time{base, base.Add(time.Hour), base.Add(2 * time.Hour)}, due)
+	assert.Equal(t, 3, count)
+
+	// The most recent activations are kept.
+	due, count = dueActivations(Every(time.Second), base, base.Add(24*time.Hour))
+	assert.Len(t, due, maxDueActivations)
+	assert.Equal(t, base.Add(24*time.Hour-(maxDueActivations-1)*time.Second), due[0])
+	assert.Equal(t, base.Add(24*time.Hour), due[len(due)-1])
+	assert.Equal(t, 24*60*60+1, count)
+
+	// The activations of a PrevSchedule are walked backwards from now, and
+	// counted up to maxDueActivations and the first one.
+	hourly, err := secondParser.Parse("0 0 * * * ?")
+	require.NoError(t, err)
+	due, count = dueActivations(hourly, base, base.Add(2*time.Hour+time.Minute))
+	assert.Equal(t, []time.Time{base, base.Add(time.Hour), base.Add(2 * time.Hour)}, due)
+	assert.Equal(t, 3, count)
+
+	secondly, err := secondParser.Parse("* * * * * ?")
+	require.NoError(t, err)
+	due, count = dueActivations(secondly, base, base.Add(24*time.Hour+500*time.Millisecond))
+	assert.Len(t, due, maxDueActivations)
+	assert.Equal(t, base.Add(24*time.Hour-(maxDueActivations-1)*time.Second), due[0])
+	assert.Equal(t, base.Add(24*time.Hour), due[len(due)-1])
+	assert.Equal(t, maxDueActivations+1, count)
+
+	// The activations of other schedules are walked forwards, at most
+	// maxDueWalk of them.
+	opaque := struct{ Schedule }{Every(time.Second)}
+	due, count = dueActivations(opaque, base, base.Add(48*time.Hour))
+	assert.Len(t, due, maxDueActivations)
+	assert.Equal(t, base.Add((maxDueWalk-1)*time.Second), due[len(due)-1])
+	assert.Equal(t, maxDueWalk, count)
+
+	due, count = dueActivations(new(ZeroSchedule), base, base.Add(time.Hour))
+	assert.Equal(t, []time.Time{base}, due)
+	assert.Equal(t, 1, count)
+}
+
+func TestCron_MisfireManyActivations(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	late := base.Add(30*time.Minute + 500*time.Millisecond)
+
+	tests := []struct {
+		name   string
+		policy MisfirePolicy
+		missed int
+	}{
+		{"fire once", MisfireFireOnce(), 1799},
+		{"grace", MisfireGrace(5 * time.Second), 1799},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := newManualClock(base)
+			missed := make(chan int, 10)
+			cron := New(WithClock(clock), WithLocation(time.UTC), WithMisfirePolicy(tt.policy))
+			id := cron.Schedule(Every(time.Second), JobFunc(func(ctx context.Context) error {
+				missed <- MissedRunsFromContext(ctx)
+				return nil
+			}))
+
+			cron.Start()
+			defer cron.Stop()
+			clock.fire(late)
+
+			assert.Equal(t, tt.missed, <-missed)
+			entry := cron.Entry(id)
+			assert.Equal(t, base.Add(30*time.Minute), entry.Prev())
+		})
+	}
+}
+
+func TestCron_Misfire(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	late := base.Add(3*time.Hour + 30*time.Minute)
+
+	tests := []struct {
+		name    string
+		cron    []Option
+		entry   []EntryOption
+		runs    int
+		missed  int
+		skipped bool
+	}{
+		{"default fires once", nil, nil, 1, 2, false},
+		{"fire all", []Option{WithMisfirePolicy(MisfireFireAll(10))}, nil, 3, 0, false},
+		{"fire all bounded", []Option{WithMisfirePolicy(MisfireFireAll(2))}, nil, 2, 1, false},
+		{"skip", []Option{WithMisfirePolicy(MisfireSkip())}, nil, 0, 3, true},
+		{"grace window", nil, []EntryOption{WithEntryMisfirePolicy(MisfireGrace(time.Hour))}, 1, 2, false},
+		{"entry overrides cron", []Option{WithMisfirePolicy(MisfireSkip())},
+			[]EntryOption{WithEntryMisfirePolicy(MisfireGrace(time.Minute))}, 0, 3, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				clock    = newManualClock(base)
+				missed   = make(chan int, 10)
+				recorder eventRecorder
+			)
+			cron := New(append([]Option{
+				WithClock(clock),
+				WithLocation(time.UTC),
+				WithEventListener(recorder.listen),
+			}, tt.cron...)...)
+
+			id, err := cron.AddEntry("@hourly", JobFunc(func(ctx context.Context) error {
+				missed <- MissedRunsFromContext(ctx)
+				return nil
+			}), tt.entry...)
+			require.NoError(t, err)
+
+			cron.Start()
+			defer cron.Stop()
+			clock.fire(late)
+
+			for i := 0; i < tt.runs; i++ {
+				assert.Equal(t, tt.missed, <-missed)
+			}
+			select {
+			case <-missed:
+				t.Fatal("expected no more runs")
+			case <-time.After(10 * time.Millisecond):
+			}
+
+			entry := cron.Entry(id)
+			assert.Equal(t, base.Add(4*time.Hour), entry.Next())
+			if tt.skipped {
+				assert.Zero(t, entry.Prev())
+			} else {
+				assert.Equal(t, base.Add(3*time.Hour), entry.Prev())
+			}
+
+			var skipped bool
+			for _, event := range recorder.Events() {
+				if e, ok := event.(JobSkipped); ok {
+					skipped = true
+					assert.Equal(t, id, e.Entry.ID())
+					assert.Equal(t, "misfire", e.Reason)
+				}
+			}
+			assert.Equal(t, tt.skipped, skipped)
+		})
+	}
+}
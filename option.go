@@ -50,3 +50,49 @@ func WithLogger(logger Logger) Option {
 		c.logger = logger
 	}
 }
+
+// WithClock overrides the clock used to drive the schedules.
+func WithClock(clock Clock) Option {
+	return func(c *Cron) {
+		c.clock = clock
+	}
+}
+
+// WithErrorHandler overrides the handler of the errors returned by jobs.
+// By default, the errors are logged with the configured Logger.
+func WithErrorHandler(handler ErrorHandler) Option {
+	return func(c *Cron) {
+		c.errHandler = handler
+	}
+}
+
+// WithEventListener subscribes the given listeners to the events emitted by the
+// Cron, see Cron.Subscribe.
+func WithEventListener(listeners ...EventListener) Option {
+	return func(c *Cron) {
+		for _, listener := range listeners {
+			c.events.subscribe(listener)
+		}
+	}
+}
+
+// WithMisfirePolicy overrides the default misfire policy of the entries,
+// see MisfirePolicy.
+func WithMisfirePolicy(policy MisfirePolicy) Option {
+	return func(c *Cron) {
+		c.misfire = policy
+	}
+}
+
+// WithMaxConcurrentJobs limits the number of jobs running at the same time to
+// n, handling the runs over the limit with the given policy. A limit of zero
+// or less means unlimited.
+func WithMaxConcurrentJobs(n int, policy ConcurrencyPolicy) Option {
+	return func(c *Cron) {
+		c.slots = nil
+		if n > 0 {
+			c.slots = make(chan struct{}, n)
+		}
+		c.concurrency = policy
+	}
+}
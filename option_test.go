@@ -50,3 +50,37 @@ func TestWithVerboseLogger(t *testing.T) {
 		t.Error("expected to see some actions, got:", out)
 	}
 }
+
+func TestWithErrorHandler(t *testing.T) {
+	c := New()
+	assert.Nil(t, c.errHandler)
+
+	var called bool
+	c = New(WithErrorHandler(func(context.Context, *Entry, error) {
+		called = true
+	}))
+	c.handleError(context.Background(), NewEntry(1, nil, NoopJob{}), assert.AnError)
+	assert.True(t, called)
+}
+
+func TestWithClock(t *testing.T) {
+	c := New()
+	assert.Equal(t, DefaultClock, c.clock)
+
+	clock := &stubClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	c = New(WithClock(clock), WithLocation(time.UTC))
+	assert.Equal(t, clock, c.clock)
+	assert.Equal(t, clock.now, c.now())
+}
+
+type stubClock struct {
+	now time.Time
+}
+
+func (s *stubClock) Now() time.Time {
+	return s.now
+}
+
+func (s *stubClock) NewTimer(d time.Duration) Timer {
+	return DefaultClock.NewTimer(d)
+}
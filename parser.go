@@ -2,7 +2,9 @@ package cron
 
 import (
 	"fmt"
+	"hash/fnv"
 	"math"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -24,6 +26,10 @@ const (
 	Dow                                    // Day of week field, default *
 	DowOptional                            // Optional day of week field, default *
 	Descriptor                             // Allow descriptors such as @monthly, @weekly, etc.
+	Hash                                   // Allow hashed values such as H, H(0-29) and H/15, see Parser.ParseWithKey.
+	Quartz                                 // Allow the Quartz L, W and # modifiers in the day fields, see Parser.Parse.
+	Year                                   // Year field, default *
+	YearOptional                           // Optional year field, default *
 )
 
 var places = []ParseOption{
@@ -33,6 +39,7 @@ var places = []ParseOption{
 	Dom,
 	Month,
 	Dow,
+	Year,
 }
 
 var defaults = []string{
@@ -42,6 +49,7 @@ var defaults = []string{
 	"*",
 	"*",
 	"*",
+	"*",
 }
 
 // Parser A custom Parser that can be configured.
@@ -67,6 +75,14 @@ type Parser struct {
 //	// Same as above, just makes Dow optional
 //	specParser := NewParser(Dom | Month | DowOptional)
 //	sched, err := specParser.Parse("15 */3")
+//
+//	// Standard parser with an optional year, e.g. "0 0 1 1 * 2027"
+//	specParser := NewParser(Minute | Hour | Dom | Month | Dow | YearOptional)
+//	sched, err := specParser.Parse("0 0 1 1 * 2026-2028")
+//
+//	// Standard parser spreading the minute of the jobs with hashed values
+//	specParser := NewParser(Minute | Hour | Dom | Month | Dow | Hash)
+//	sched, err := specParser.ParseWithKey("H H(0-5) * * *", "nightly-backup")
 func NewParser(options ParseOption) Parser {
 	optionals := 0
 	if options&DowOptional > 0 {
@@ -75,6 +91,9 @@ func NewParser(options ParseOption) Parser {
 	if options&SecondOptional > 0 {
 		optionals++
 	}
+	if options&YearOptional > 0 {
+		optionals++
+	}
 	if optionals > 1 {
 		panic("multiple optionals may not be configured")
 	}
@@ -84,7 +103,34 @@ func NewParser(options ParseOption) Parser {
 // Parse returns a new crontab schedule representing the given spec.
 // It returns a descriptive error if the spec is not valid.
 // It accepts crontab specs and features configured by NewParser.
+// Hashed values are resolved with an empty key, see ParseWithKey.
+//
+// With the Quartz option, the day of month field also accepts
+//
+//	L     the last day of the month
+//	L-n   n days before the last day of the month
+//	nW    the weekday (Monday to Friday) nearest to day n, within the month
+//	LW    the last weekday of the month
+//
+// and the day of week field, whose days are numbered from 0 for Sunday,
+//
+//	nL    the last day n of the month, e.g. 5L for the last Friday
+//	n#k   the k-th day n of the month, e.g. 2#2 for the second Tuesday
 func (p Parser) Parse(spec string) (Schedule, error) {
+	return p.ParseWithKey(spec, "")
+}
+
+// ParseWithKey is like Parse, but resolves the hashed values of the spec from
+// the given key, e.g. the name of the entry, if the parser accepts them, see
+// Hash. A hashed value is a stable pseudo-random value of its field derived
+// from the key, which spreads the activations of schedules sharing the same
+// spec while keeping them the same across restarts:
+//
+//	H         a value within the bounds of the field, 1-28 for day of month
+//	H(a-b)    a value within a-b
+//	H/n       every n values from a value below n, e.g. 7,22,37,52 for H/15
+//	H(a-b)/n  every n values within a-b from a value below a+n
+func (p Parser) ParseWithKey(spec, key string) (Schedule, error) {
 	if len(spec) == 0 {
 		return nil, fmt.Errorf("empty spec string")
 	}
@@ -119,36 +165,127 @@ func (p Parser) Parse(spec string) (Schedule, error) {
 		return nil, err
 	}
 
-	field := func(field string, r bounds) uint64 {
+	schedule := &SpecSchedule{Location: loc}
+	place := 0
+	field := func(field string, r bounds, modifier func(expr string) (bool, error)) uint64 {
+		place++
 		if err != nil {
 			return 0
 		}
+		if isHashed(field) {
+			if p.options&Hash == 0 {
+				err = fmt.Errorf("parser does not accept hashed values: %s", field)
+				return 0
+			}
+			if field, err = expandHash(field, r, hashOf(key, place)); err != nil {
+				return 0
+			}
+		}
+		if modifier != nil && p.options&Quartz > 0 {
+			if field, err = extractModifiers(field, modifier); err != nil || field == "" {
+				return 0
+			}
+		}
 		var bits uint64
 		bits, err = getField(field, r)
 		return bits
 	}
 
-	var (
-		second     = field(fields[0], seconds)
-		minute     = field(fields[1], minutes)
-		hour       = field(fields[2], hours)
-		dayofmonth = field(fields[3], dom)
-		month      = field(fields[4], months)
-		dayofweek  = field(fields[5], dow)
-	)
+	schedule.Second = field(fields[0], seconds, nil)
+	schedule.Minute = field(fields[1], minutes, nil)
+	schedule.Hour = field(fields[2], hours, nil)
+	schedule.Dom = field(fields[3], dom, schedule.parseDomModifier)
+	schedule.Month = field(fields[4], months, nil)
+	schedule.Dow = field(fields[5], dow, schedule.parseDowModifier)
 	if err != nil {
 		return nil, err
 	}
+	if schedule.Year, err = getYears(fields[6]); err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+// extractModifiers passes each expression of the field to the modifier parser
+// and returns the field without the expressions it has handled.
+func extractModifiers(field string, modifier func(expr string) (bool, error)) (string, error) {
+	var rest []string
+	for _, expr := range strings.Split(field, ",") {
+		handled, err := modifier(expr)
+		if err != nil {
+			return "", err
+		}
+		if !handled {
+			rest = append(rest, expr)
+		}
+	}
+	return strings.Join(rest, ","), nil
+}
 
-	return &SpecSchedule{
-		Second:   second,
-		Minute:   minute,
-		Hour:     hour,
-		Dom:      dayofmonth,
-		Month:    month,
-		Dow:      dayofweek,
-		Location: loc,
-	}, nil
+// parseDomModifier parses the L, L-n, LW and nW day of month expressions,
+// reporting whether expr is one of them.
+func (s *SpecSchedule) parseDomModifier(expr string) (bool, error) {
+	switch {
+	case expr == "L":
+		s.LastDom |= 1
+	case expr == "LW":
+		s.WeekdayDom |= 1
+	case strings.HasPrefix(expr, "L-"):
+		n, err := mustParseInt(expr[2:])
+		if err != nil {
+			return false, err
+		}
+		if n > dom.max-1 {
+			return false, fmt.Errorf("offset from the last day of the month (%d) above maximum (%d): %s", n, dom.max-1, expr)
+		}
+		s.LastDom |= 1 << n
+	case strings.HasSuffix(expr, "W"):
+		n, err := mustParseInt(expr[:len(expr)-1])
+		if err != nil {
+			return false, err
+		}
+		if n < dom.min || n > dom.max {
+			return false, fmt.Errorf("day of month (%d) out of bounds (%d-%d): %s", n, dom.min, dom.max, expr)
+		}
+		s.WeekdayDom |= 1 << n
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// parseDowModifier parses the nL and n#k day of week expressions, reporting
+// whether expr is one of them.
+func (s *SpecSchedule) parseDowModifier(expr string) (bool, error) {
+	if day, nth, ok := strings.Cut(expr, "#"); ok {
+		n, err := parseIntOrName(day, dow.names)
+		if err != nil {
+			return false, err
+		}
+		k, err := mustParseInt(nth)
+		if err != nil {
+			return false, err
+		}
+		if n > dow.max || k < 1 || k > 5 {
+			return false, fmt.Errorf("day of week (%d) or occurrence (%d) out of bounds (%d-%d, 1-5): %s",
+				n, k, dow.min, dow.max, expr)
+		}
+		s.NthDow |= 1 << (7*(k-1) + n)
+		return true, nil
+	}
+	if day, ok := strings.CutSuffix(expr, "L"); ok {
+		n, err := parseIntOrName(day, dow.names)
+		if err != nil {
+			return false, err
+		}
+		if n > dow.max {
+			return false, fmt.Errorf("day of week (%d) above maximum (%d): %s", n, dow.max, expr)
+		}
+		s.LastDow |= 1 << n
+		return true, nil
+	}
+	return false, nil
 }
 
 // normalizeFields takes a subset set of the time fields and returns the full set
@@ -167,6 +304,10 @@ func normalizeFields(fields []string, options ParseOption) ([]string, error) {
 		options |= Dow
 		optionals++
 	}
+	if options&YearOptional > 0 {
+		options |= Year
+		optionals++
+	}
 	if optionals > 1 {
 		return nil, fmt.Errorf("multiple optionals may not be configured")
 	}
@@ -192,9 +333,15 @@ func normalizeFields(fields []string, options ParseOption) ([]string, error) {
 	if _min < _max && len(fields) == _min {
 		switch {
 		case options&DowOptional > 0:
-			fields = append(fields, defaults[5]) // TODO: improve access to default
+			i := len(fields)
+			if options&Year > 0 {
+				i--
+			}
+			fields = slices.Insert(fields, i, defaults[5]) // TODO: improve access to default
 		case options&SecondOptional > 0:
 			fields = append([]string{defaults[0]}, fields...)
+		case options&YearOptional > 0:
+			fields = append(fields, defaults[6])
 		default:
 			return nil, fmt.Errorf("unknown optional field")
 		}
@@ -229,6 +376,82 @@ func ParseStandard(standardSpec string) (Schedule, error) {
 	return standardParser.Parse(standardSpec)
 }
 
+// hashOf returns the hash of the given field of the schedules parsed with key.
+func hashOf(key string, field int) uint {
+	h := fnv.New64a()
+	_, _ = fmt.Fprintf(h, "%s\x00%d", key, field)
+	return uint(h.Sum64() >> 1)
+}
+
+// isHashed reports whether one of the expressions of the field is a hashed
+// value, i.e. starts with H, as opposed to names such as THU.
+func isHashed(field string) bool {
+	for _, expr := range strings.Split(field, ",") {
+		if strings.HasPrefix(expr, "H") {
+			return true
+		}
+	}
+	return false
+}
+
+// expandHash replaces the hashed values of the field, see Parser.ParseWithKey,
+// with the plain ranges they resolve to for the given hash.
+func expandHash(field string, r bounds, hash uint) (string, error) {
+	exprs := strings.Split(field, ",")
+	for i, expr := range exprs {
+		if !strings.HasPrefix(expr, "H") {
+			continue
+		}
+
+		// Days of month above 28 do not exist in every month.
+		low, high := r.min, r.max
+		if r.min == dom.min && r.max == dom.max {
+			high = 28
+		}
+
+		rest := expr[1:]
+		if strings.HasPrefix(rest, "(") {
+			end := strings.Index(rest, ")")
+			if end < 0 {
+				return "", fmt.Errorf("missing closing parenthesis: %s", expr)
+			}
+			lowAndHigh := strings.Split(rest[1:end], "-")
+			if len(lowAndHigh) != 2 {
+				return "", fmt.Errorf("hashed range should be H(low-high): %s", expr)
+			}
+			var err error
+			if low, err = parseIntOrName(lowAndHigh[0], r.names); err != nil {
+				return "", err
+			}
+			if high, err = parseIntOrName(lowAndHigh[1], r.names); err != nil {
+				return "", err
+			}
+			if low < r.min || high > r.max || low > high {
+				return "", fmt.Errorf("hashed range (%d-%d) out of bounds (%d-%d): %s", low, high, r.min, r.max, expr)
+			}
+			rest = rest[end+1:]
+		}
+
+		switch {
+		case rest == "":
+			exprs[i] = strconv.FormatUint(uint64(low+hash%(high-low+1)), 10)
+		case strings.HasPrefix(rest, "/"):
+			step, err := mustParseInt(rest[1:])
+			if err != nil {
+				return "", err
+			}
+			if step == 0 {
+				return "", fmt.Errorf("step of range should be a positive number: %s", expr)
+			}
+			start := low + hash%min(step, high-low+1)
+			exprs[i] = fmt.Sprintf("%d-%d/%d", start, high, step)
+		default:
+			return "", fmt.Errorf("failed to parse hashed value: %s", expr)
+		}
+	}
+	return strings.Join(exprs, ","), nil
+}
+
 // getField returns an Int with the bits set representing all of the times that
 // the field represents or error parsing field value.  A "field" is a comma-separated
 // list of "ranges".
@@ -251,15 +474,42 @@ func getField(field string, r bounds) (uint64, error) {
 //
 // or error parsing range.
 func getRange(expr string, r bounds) (uint64, error) {
+	start, end, step, extra, err := parseRange(expr, r)
+	if err != nil {
+		return 0, err
+	}
+	return getBits(start, end, step) | extra, nil
+}
+
+// getYears returns the bit set of years indicated by the given year field, see
+// SpecSchedule.Year, or the zero value if the field accepts any year.
+func getYears(field string) ([3]uint64, error) {
+	var bits [3]uint64
+	for _, expr := range strings.FieldsFunc(field, func(r rune) bool { return r == ',' }) {
+		start, end, step, extra, err := parseRange(expr, years)
+		if err != nil {
+			return [3]uint64{}, err
+		}
+		if extra&starBit > 0 {
+			return [3]uint64{}, nil
+		}
+		for y := start; y <= end; y += step {
+			n := y - years.min
+			bits[n/64] |= 1 << (n % 64)
+		}
+	}
+	return bits, nil
+}
+
+// parseRange returns the start, end and step of the given range expression,
+// and the star bit if it is a star, see getRange.
+func parseRange(expr string, r bounds) (start, end, step uint, extra uint64, err error) {
 	var (
-		start, end, step uint
-		rangeAndStep     = strings.Split(expr, "/")
-		lowAndHigh       = strings.Split(rangeAndStep[0], "-")
-		singleDigit      = len(lowAndHigh) == 1
-		err              error
+		rangeAndStep = strings.Split(expr, "/")
+		lowAndHigh   = strings.Split(rangeAndStep[0], "-")
+		singleDigit  = len(lowAndHigh) == 1
 	)
 
-	var extra uint64
 	if lowAndHigh[0] == "*" || lowAndHigh[0] == "?" {
 		start = r.min
 		end = r.max
@@ -267,7 +517,7 @@ func getRange(expr string, r bounds) (uint64, error) {
 	} else {
 		start, err = parseIntOrName(lowAndHigh[0], r.names)
 		if err != nil {
-			return 0, err
+			return 0, 0, 0, 0, err
 		}
 		switch len(lowAndHigh) {
 		case 1:
@@ -275,10 +525,10 @@ func getRange(expr string, r bounds) (uint64, error) {
 		case 2:
 			end, err = parseIntOrName(lowAndHigh[1], r.names)
 			if err != nil {
-				return 0, err
+				return 0, 0, 0, 0, err
 			}
 		default:
-			return 0, fmt.Errorf("too many hyphens: %s", expr)
+			return 0, 0, 0, 0, fmt.Errorf("too many hyphens: %s", expr)
 		}
 	}
 
@@ -288,7 +538,7 @@ func getRange(expr string, r bounds) (uint64, error) {
 	case 2: //nolint:mnd
 		step, err = mustParseInt(rangeAndStep[1])
 		if err != nil {
-			return 0, err
+			return 0, 0, 0, 0, err
 		}
 
 		// Special handling: "N/step" means "N-max/step".
@@ -299,23 +549,23 @@ func getRange(expr string, r bounds) (uint64, error) {
 			extra = 0
 		}
 	default:
-		return 0, fmt.Errorf("too many slashes: %s", expr)
+		return 0, 0, 0, 0, fmt.Errorf("too many slashes: %s", expr)
 	}
 
 	if start < r.min {
-		return 0, fmt.Errorf("beginning of range (%d) below minimum (%d): %s", start, r.min, expr)
+		return 0, 0, 0, 0, fmt.Errorf("beginning of range (%d) below minimum (%d): %s", start, r.min, expr)
 	}
 	if end > r.max {
-		return 0, fmt.Errorf("end of range (%d) above maximum (%d): %s", end, r.max, expr)
+		return 0, 0, 0, 0, fmt.Errorf("end of range (%d) above maximum (%d): %s", end, r.max, expr)
 	}
 	if start > end {
-		return 0, fmt.Errorf("beginning of range (%d) beyond end of range (%d): %s", start, end, expr)
+		return 0, 0, 0, 0, fmt.Errorf("beginning of range (%d) beyond end of range (%d): %s", start, end, expr)
 	}
 	if step == 0 {
-		return 0, fmt.Errorf("step of range should be a positive number: %s", expr)
+		return 0, 0, 0, 0, fmt.Errorf("step of range should be a positive number: %s", expr)
 	}
 
-	return getBits(start, end, step) | extra, nil
+	return start, end, step, extra, nil
 }
 
 // parseIntOrName returns the (possibly-named) integer contained in expr.
@@ -214,43 +214,61 @@ func TestNormalizeFields(t *testing.T) {
 			"AllFields_NoOptional",
 			[]string{"0", "5", "*", "*", "*", "*"},
 			Second | Minute | Hour | Dom | Month | Dow | Descriptor,
-			[]string{"0", "5", "*", "*", "*", "*"},
+			[]string{"0", "5", "*", "*", "*", "*", "*"},
 		},
 		{
 			"AllFields_SecondOptional_Provided",
 			[]string{"0", "5", "*", "*", "*", "*"},
 			SecondOptional | Minute | Hour | Dom | Month | Dow | Descriptor,
-			[]string{"0", "5", "*", "*", "*", "*"},
+			[]string{"0", "5", "*", "*", "*", "*", "*"},
 		},
 		{
 			"AllFields_SecondOptional_NotProvided",
 			[]string{"5", "*", "*", "*", "*"},
 			SecondOptional | Minute | Hour | Dom | Month | Dow | Descriptor,
-			[]string{"0", "5", "*", "*", "*", "*"},
+			[]string{"0", "5", "*", "*", "*", "*", "*"},
 		},
 		{
 			"SubsetFields_NoOptional",
 			[]string{"5", "15", "*"},
 			Hour | Dom | Month,
-			[]string{"0", "0", "5", "15", "*", "*"},
+			[]string{"0", "0", "5", "15", "*", "*", "*"},
 		},
 		{
 			"SubsetFields_DowOptional_Provided",
 			[]string{"5", "15", "*", "4"},
 			Hour | Dom | Month | DowOptional,
-			[]string{"0", "0", "5", "15", "*", "4"},
+			[]string{"0", "0", "5", "15", "*", "4", "*"},
 		},
 		{
 			"SubsetFields_DowOptional_NotProvided",
 			[]string{"5", "15", "*"},
 			Hour | Dom | Month | DowOptional,
-			[]string{"0", "0", "5", "15", "*", "*"},
+			[]string{"0", "0", "5", "15", "*", "*", "*"},
 		},
 		{
 			"SubsetFields_SecondOptional_NotProvided",
 			[]string{"5", "15", "*"},
 			SecondOptional | Hour | Dom | Month,
-			[]string{"0", "0", "5", "15", "*", "*"},
+			[]string{"0", "0", "5", "15", "*", "*", "*"},
+		},
+		{
+			"AllFields_Year",
+			[]string{"5", "*", "*", "*", "*", "2027"},
+			Minute | Hour | Dom | Month | Dow | Year,
+			[]string{"0", "5", "*", "*", "*", "*", "2027"},
+		},
+		{
+			"AllFields_YearOptional_NotProvided",
+			[]string{"5", "*", "*", "*", "*"},
+			Minute | Hour | Dom | Month | Dow | YearOptional,
+			[]string{"0", "5", "*", "*", "*", "*", "*"},
+		},
+		{
+			"SubsetFields_DowOptional_NotProvided_Year",
+			[]string{"5", "15", "*", "2027"},
+			Hour | Dom | Month | DowOptional | Year,
+			[]string{"0", "0", "5", "15", "*", "*", "2027"},
 		},
 	}
 
@@ -280,6 +298,12 @@ func TestNormalizeFields_Errors(t *testing.T) {
 			SecondOptional | Minute | Hour | Dom | Month | DowOptional,
 			"",
 		},
+		{
+			"YearAndDowOptionals",
+			[]string{"0", "5", "*", "*", "*"},
+			Minute | Hour | Dom | Month | DowOptional | YearOptional,
+			"",
+		},
 		{
 			"TooManyFields",
 			[]string{"0", "5", "*", "*"},
@@ -319,8 +343,11 @@ func TestStandardSpecSchedule(t *testing.T) {
 		err      string
 	}{
 		{
-			expr:     "5 * * * *",
-			expected: &SpecSchedule{1 << seconds.min, 1 << 5, all(hours), all(dom), all(months), all(dow), time.Local},
+			expr: "5 * * * *",
+			expected: &SpecSchedule{
+				Second: 1 << seconds.min, Minute: 1 << 5, Hour: all(hours),
+				Dom: all(dom), Month: all(months), Dow: all(dow), Location: time.Local,
+			},
 		},
 		{
 			expr:     "@every 5m",
@@ -359,15 +386,21 @@ func TestNoDescriptorParser(t *testing.T) {
 }
 
 func every5min(loc *time.Location) *SpecSchedule {
-	return &SpecSchedule{1 << 0, 1 << 5, all(hours), all(dom), all(months), all(dow), loc}
+	return &SpecSchedule{
+		Second: 1 << 0, Minute: 1 << 5, Hour: all(hours),
+		Dom: all(dom), Month: all(months), Dow: all(dow), Location: loc,
+	}
 }
 
 func every5min5s(loc *time.Location) *SpecSchedule {
-	return &SpecSchedule{1 << 5, 1 << 5, all(hours), all(dom), all(months), all(dow), loc}
+	return &SpecSchedule{
+		Second: 1 << 5, Minute: 1 << 5, Hour: all(hours),
+		Dom: all(dom), Month: all(months), Dow: all(dow), Location: loc,
+	}
 }
 
 func midnight(loc *time.Location) *SpecSchedule {
-	return &SpecSchedule{1, 1, 1, all(dom), all(months), all(dow), loc}
+	return &SpecSchedule{Second: 1, Minute: 1, Hour: 1, Dom: all(dom), Month: all(months), Dow: all(dow), Location: loc}
 }
 
 func annual(loc *time.Location) *SpecSchedule {
@@ -381,3 +414,140 @@ func annual(loc *time.Location) *SpecSchedule {
 		Location: loc,
 	}
 }
+
+func TestParseWithKey_Hash(t *testing.T) {
+	parser := NewParser(Second | Minute | Hour | Dom | Month | Dow | Hash)
+
+	parse := func(spec, key string) *SpecSchedule {
+		t.Helper()
+		schedule, err := parser.ParseWithKey(spec, key)
+		if err != nil {
+			t.Fatalf("%s: %v", spec, err)
+		}
+		return schedule.(*SpecSchedule)
+	}
+	bitsIn := func(bits uint64, low, high uint) []uint {
+		var values []uint
+		for i := low; i <= high; i++ {
+			if bits&(1<<i) > 0 {
+				values = append(values, i)
+			}
+		}
+		return values
+	}
+
+	// Hashed values are stable for a key and spread across keys.
+	minutesOf := map[uint]bool{}
+	for i := 0; i < 20; i++ {
+		key := "job-" + string(rune('a'+i))
+		s := parse("0 H * * * *", key)
+		if !reflect.DeepEqual(s, parse("0 H * * * *", key)) {
+			t.Errorf("%s: hashed value is not stable", key)
+		}
+		minute := bitsIn(s.Minute, 0, 59)
+		if len(minute) != 1 {
+			t.Fatalf("%s: expected one minute, got %v", key, minute)
+		}
+		minutesOf[minute[0]] = true
+	}
+	if len(minutesOf) < 5 {
+		t.Errorf("expected hashed minutes to be spread, got %v", minutesOf)
+	}
+
+	// Each field is hashed independently.
+	s := parse("H H H H H H", "key")
+	if s.Second == s.Minute && s.Minute == s.Hour {
+		t.Errorf("expected fields to be hashed independently, got %v", s)
+	}
+	if days := bitsIn(s.Dom, 1, 31); len(days) != 1 || days[0] > 28 {
+		t.Errorf("expected a day of month within 1-28, got %v", days)
+	}
+
+	for i := 0; i < 20; i++ {
+		key := "job-" + string(rune('a'+i))
+
+		s := parse("0 H(0-29) * * * *", key)
+		if minute := bitsIn(s.Minute, 0, 59); len(minute) != 1 || minute[0] > 29 {
+			t.Errorf("%s: expected a minute within 0-29, got %v", key, minute)
+		}
+
+		s = parse("0 H/15 * * * *", key)
+		minute := bitsIn(s.Minute, 0, 59)
+		if len(minute) != 4 || minute[0] >= 15 ||
+			minute[1] != minute[0]+15 || minute[2] != minute[0]+30 || minute[3] != minute[0]+45 {
+			t.Errorf("%s: expected every 15 minutes, got %v", key, minute)
+		}
+
+		s = parse("0 0 H(8-17)/4,23 * * *", key)
+		hour := bitsIn(s.Hour, 0, 23)
+		var want []uint
+		for h := hour[0]; h <= 17; h += 4 {
+			want = append(want, h)
+		}
+		if hour[0] < 8 || hour[0] >= 12 || !reflect.DeepEqual(append(want, 23), hour) {
+			t.Errorf("%s: expected every 4 hours within 8-17 and 23, got %v", key, hour)
+		}
+
+		s = parse("0 0 0 * * H(mon-fri)", key)
+		if day := bitsIn(s.Dow, 0, 6); len(day) != 1 || day[0] < 1 || day[0] > 5 {
+			t.Errorf("%s: expected a weekday, got %v", key, day)
+		}
+	}
+
+	for _, spec := range []string{
+		"0 H( * * * *",
+		"0 H(0) * * * *",
+		"0 H(0-60) * * * *",
+		"0 H(30-10) * * * *",
+		"0 H/0 * * * *",
+		"0 H/x * * * *",
+		"0 Hx * * * *",
+	} {
+		if _, err := parser.Parse(spec); err == nil {
+			t.Errorf("%s: expected an error", spec)
+		}
+	}
+
+	if _, err := secondParser.Parse("0 H * * * *"); err == nil || !strings.Contains(err.Error(), "hashed values") {
+		t.Errorf("expected the parser to reject hashed values, got %v", err)
+	}
+
+	// Names containing an H are not hashed values, with or without Hash.
+	for _, p := range []Parser{standardParser, NewParser(Minute | Hour | Dom | Month | Dow | Hash)} {
+		for _, spec := range []string{"0 0 * * THU", "0 0 * * MON-THU", "0 0 * * sun,THU"} {
+			s, err := p.Parse(spec)
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", spec, err)
+				continue
+			}
+			if 1<<4&s.(*SpecSchedule).Dow == 0 {
+				t.Errorf("%s: expected Thursday to be set", spec)
+			}
+		}
+	}
+}
+
+func TestParseQuartzErrors(t *testing.T) {
+	parser := NewParser(Minute | Hour | Dom | Month | Dow | Quartz)
+	tests := []struct{ expr, err string }{
+		{"0 0 32W * *", "out of bounds"},
+		{"0 0 L-31 * *", "above maximum"},
+		{"0 0 L-x * *", "failed to parse int from"},
+		{"0 0 * * 7L", "above maximum"},
+		{"0 0 * * 1#6", "out of bounds"},
+		{"0 0 * * 1#0", "out of bounds"},
+	}
+	for _, c := range tests {
+		actual, err := parser.Parse(c.expr)
+		if err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("%s => expected %v, got %v", c.expr, c.err, err)
+		}
+		if actual != nil {
+			t.Errorf("expected nil schedule on error, got %v", actual)
+		}
+	}
+
+	if _, err := standardParser.Parse("0 0 L * *"); err == nil {
+		t.Error("expected an error parsing L without the Quartz option")
+	}
+}
@@ -0,0 +1,133 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// SlogLogger wraps a *slog.Logger into an implementation of the Logger
+// interface. The key/values are passed to slog as is, so entry ids and times
+// keep their types, errors are logged with an "error" attribute, and the
+// scheduler's routine messages are logged at the debug level.
+func SlogLogger(l *slog.Logger) DebugLogger {
+	return slogLogger{l}
+}
+
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+func (sl slogLogger) Debug(msg string, keysAndValues ...any) {
+	sl.logger.Debug(msg, keysAndValues...)
+}
+
+func (sl slogLogger) Info(msg string, keysAndValues ...any) {
+	sl.logger.Info(msg, keysAndValues...)
+}
+
+func (sl slogLogger) Error(err error, msg string, keysAndValues ...any) {
+	sl.logger.Error(msg, append([]any{"error", err}, keysAndValues...)...)
+}
+
+// SlogHandler returns a slog.Handler writing the records to the given Logger,
+// so that code logging with slog can use it through slog.New. Records at the
+// error level or above are passed to Error with the error held by their
+// "error" attribute if any, the others to Info, or to Debug for the records
+// below the info level if the Logger is a DebugLogger.
+func SlogHandler(l Logger) slog.Handler {
+	return &slogHandler{logger: l}
+}
+
+type slogHandler struct {
+	logger Logger
+	attrs  []any
+	group  string
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	if level < slog.LevelInfo {
+		_, ok := h.logger.(DebugLogger)
+		return ok
+	}
+	return true
+}
+
+func (h *slogHandler) Handle(_ context.Context, r slog.Record) error {
+	keysAndValues := append([]any(nil), h.attrs...)
+	var err error
+	r.Attrs(func(a slog.Attr) bool {
+		if e, ok := a.Value.Any().(error); ok && a.Key == "error" && err == nil {
+			err = e
+			return true
+		}
+		keysAndValues = h.appendAttr(keysAndValues, h.group, a)
+		return true
+	})
+
+	switch {
+	case r.Level >= slog.LevelError:
+		if err == nil {
+			err = errors.New(r.Message)
+		}
+		h.logger.Error(err, r.Message, keysAndValues...)
+	case r.Level < slog.LevelInfo:
+		if d, ok := h.logger.(DebugLogger); ok {
+			d.Debug(r.Message, keysAndValues...)
+		}
+	default:
+		if err != nil {
+			keysAndValues = append(keysAndValues, "error", err)
+		}
+		h.logger.Info(r.Message, keysAndValues...)
+	}
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	h2 := *h
+	h2.attrs = append([]any(nil), h.attrs...)
+	for _, a := range attrs {
+		h2.attrs = h.appendAttr(h2.attrs, h.group, a)
+	}
+	return &h2
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.group = joinGroup(h.group, name)
+	return &h2
+}
+
+// appendAttr appends the key/value of the attribute, qualified by the group,
+// flattening the groups into dotted keys.
+func (h *slogHandler) appendAttr(keysAndValues []any, group string, a slog.Attr) []any {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return keysAndValues
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		group = joinGroup(group, a.Key)
+		for _, ga := range a.Value.Group() {
+			keysAndValues = h.appendAttr(keysAndValues, group, ga)
+		}
+		return keysAndValues
+	}
+	return append(keysAndValues, joinGroup(group, a.Key), a.Value.Any())
+}
+
+func joinGroup(group, key string) string {
+	if group == "" {
+		return key
+	}
+	if key == "" {
+		return group
+	}
+	return group + "." + key
+}
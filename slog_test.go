@@ -0,0 +1,117 @@
+package cron
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := SlogLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	logger.Info("added", "entry", EntryID(1), "next", now)
+	logger.Error(errors.New("boom"), "job failed", "entry", EntryID(2))
+	logger.Debug("wake", "now", now)
+
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		delete(record, "time")
+		records = append(records, record)
+	}
+	assert.Equal(t, []map[string]any{
+		{"level": "INFO", "msg": "added", "entry": 1.0, "next": "2024-01-01T00:00:00Z"},
+		{"level": "ERROR", "msg": "job failed", "error": "boom", "entry": 2.0},
+		{"level": "DEBUG", "msg": "wake", "now": "2024-01-01T00:00:00Z"},
+	}, records)
+}
+
+func TestSlogLogger_Cron(t *testing.T) {
+	var buf syncWriter
+	handler := slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+	cron := New(WithLogger(SlogLogger(slog.New(handler))))
+	_, err := cron.AddFunc("@every 1h", func(context.Context) error { return nil })
+	require.NoError(t, err)
+
+	cron.Start()
+	cron.Stop()
+	_, err = cron.AddFunc("@every 1h", func(context.Context) error { return nil })
+	require.NoError(t, err)
+
+	// The routine messages are logged at the debug level.
+	assert.Contains(t, buf.String(), "msg=start")
+	assert.Contains(t, buf.String(), "msg=stop")
+	assert.NotContains(t, buf.String(), "msg=schedule")
+}
+
+// recordingLogger records the messages it is given.
+type recordingLogger struct {
+	messages []string
+	values   [][]any
+	errs     []error
+}
+
+func (l *recordingLogger) Info(msg string, keysAndValues ...any) {
+	l.messages = append(l.messages, "info: "+msg)
+	l.values = append(l.values, keysAndValues)
+}
+
+func (l *recordingLogger) Error(err error, msg string, keysAndValues ...any) {
+	l.messages = append(l.messages, "error: "+msg)
+	l.values = append(l.values, keysAndValues)
+	l.errs = append(l.errs, err)
+}
+
+type recordingDebugLogger struct {
+	recordingLogger
+}
+
+func (l *recordingDebugLogger) Debug(msg string, keysAndValues ...any) {
+	l.messages = append(l.messages, "debug: "+msg)
+	l.values = append(l.values, keysAndValues)
+}
+
+func TestSlogHandler(t *testing.T) {
+	var (
+		recorder recordingLogger
+		logger   = slog.New(SlogHandler(&recorder))
+		now      = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		errBoom  = errors.New("boom")
+	)
+
+	logger.Debug("dropped")
+	logger.Info("info", "now", now)
+	logger.With("entry", 1).WithGroup("job").Warn("warn", "name", "report", slog.Group("run", "attempt", 2))
+	logger.Error("failed", "error", errBoom, "entry", 1)
+	logger.Error("failed without error")
+
+	assert.False(t, logger.Enabled(context.Background(), slog.LevelDebug))
+	assert.Equal(t, []string{
+		"info: info", "info: warn", "error: failed", "error: failed without error",
+	}, recorder.messages)
+	assert.Equal(t, [][]any{
+		{"now", now},
+		{"entry", int64(1), "job.name", "report", "job.run.attempt", int64(2)},
+		{"entry", int64(1)},
+		nil,
+	}, recorder.values)
+	assert.Equal(t, errBoom, recorder.errs[0])
+	assert.EqualError(t, recorder.errs[1], "failed without error")
+
+	var debug recordingDebugLogger
+	logger = slog.New(SlogHandler(&debug))
+	assert.True(t, logger.Enabled(context.Background(), slog.LevelDebug))
+	logger.Debug("debug", "now", now)
+	assert.Equal(t, []string{"debug: debug"}, debug.messages)
+}
@@ -1,12 +1,28 @@
 package cron
 
-import "time"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // SpecSchedule specifies a duty cycle (to the second granularity), based on a
 // traditional crontab specification. It is computed initially and stored as bit sets.
 type SpecSchedule struct {
 	Second, Minute, Hour, Dom, Month, Dow uint64
 
+	// Days relative to the month, which bit sets of days can't represent, see
+	// the Quartz parse option. They match in addition to Dom and Dow.
+	LastDom    uint64 // bit n: n days before the last day of the month (L, L-n)
+	WeekdayDom uint64 // bit n: weekday nearest to day n (nW), or last weekday for n = 0 (LW)
+	LastDow    uint64 // bit n: last weekday n of the month (nL)
+	NthDow     uint64 // bit 7*(k-1)+n: k-th weekday n of the month (n#k)
+
+	// Accepted years, see the Year parse option: bit n%64 of Year[n/64] is
+	// set for the year 1970+n. The zero value accepts any year.
+	Year [3]uint64
+
 	// Override location for this schedule.
 	Location *time.Location
 }
@@ -46,6 +62,7 @@ var (
 		"fri": 5,
 		"sat": 6,
 	}}
+	years = bounds{1970, 2099, nil}
 )
 
 const (
@@ -84,14 +101,28 @@ func (s *SpecSchedule) Next(t time.Time) time.Time {
 	// This flag indicates whether a field has been incremented.
 	added := false
 
-	// If no time is found within five years, return zero.
+	// If no time is found within five years, or up to the last accepted year
+	// if the schedule has years, return zero.
 	yearLimit := t.Year() + 5
+	if s.hasYears() {
+		yearLimit, _ = s.prevYear(int(years.max))
+	}
 
 WRAP:
 	if t.Year() > yearLimit {
 		return time.Time{}
 	}
 
+	// Find the first accepted year.
+	year, ok := s.nextYear(t.Year())
+	if !ok || year > yearLimit {
+		return time.Time{}
+	}
+	if year != t.Year() {
+		added = true
+		t = time.Date(year, time.January, 1, 0, 0, 0, 0, loc)
+	}
+
 	// Find the first applicable month.
 	// If it's this month, then do nothing.
 	for 1<<uint(t.Month())&s.Month == 0 {
@@ -174,15 +205,352 @@ WRAP:
 	return t.In(origLocation)
 }
 
+// Prev returns the previous time this schedule was activated, earlier than the
+// given time. If no time can be found to satisfy the schedule, return the zero
+// time. It handles daylight saving time like Next: activations in a skipped
+// hour are missed and activations in a repeated hour occur twice.
+func (s *SpecSchedule) Prev(t time.Time) time.Time {
+	// Same approach as Next, walking backwards: when a field doesn't match,
+	// go to the last second of its previous value, wrapping around to re-verify
+	// the coarser fields when needed.
+	origLocation := t.Location()
+	loc := s.Location
+	if loc == time.Local {
+		loc = t.Location()
+	}
+	if s.Location != time.Local {
+		t = t.In(s.Location)
+	}
+
+	// Start at the latest possible time (the previous second).
+	if t.Nanosecond() > 0 {
+		t = t.Add(-time.Duration(t.Nanosecond()) * time.Nanosecond)
+	} else {
+		t = t.Add(-1 * time.Second)
+	}
+
+	// If no time is found within five years, or down to the first accepted
+	// year if the schedule has years, return zero.
+	yearLimit := t.Year() - 5
+	if s.hasYears() {
+		yearLimit, _ = s.nextYear(int(years.min))
+	}
+
+WRAP:
+	if t.Year() < yearLimit {
+		return time.Time{}
+	}
+
+	// Find the last accepted year.
+	year, ok := s.prevYear(t.Year())
+	if !ok || year < yearLimit {
+		return time.Time{}
+	}
+	if year != t.Year() {
+		t = time.Date(year, time.December, 31, 23, 59, 59, 0, loc)
+	}
+
+	for 1<<uint(t.Month())&s.Month == 0 {
+		// Go to the last second of the previous month.
+		t = time.Date(t.Year(), t.Month(), 0, 23, 59, 59, 0, loc)
+
+		// Wrapped around.
+		if t.Month() == time.December {
+			goto WRAP
+		}
+	}
+
+	// Days are stepped through with dates rather than durations, since
+	// midnight may not exist (or exist twice) on the days of DST transitions.
+	for !dayMatches(s, t) {
+		month := t.Month()
+		t = time.Date(t.Year(), t.Month(), t.Day()-1, 23, 59, 59, 0, loc)
+
+		if t.Month() != month {
+			goto WRAP
+		}
+	}
+
+	// Hours, minutes and seconds are stepped through with durations, so that
+	// repeated hours are visited twice and skipped hours not at all.
+	for 1<<uint(t.Hour())&s.Hour == 0 {
+		t = t.Add(-time.Duration(t.Minute())*time.Minute - time.Duration(t.Second()+1)*time.Second)
+
+		if t.Hour() == 23 {
+			goto WRAP
+		}
+	}
+
+	for 1<<uint(t.Minute())&s.Minute == 0 {
+		t = t.Add(-time.Duration(t.Second()+1) * time.Second)
+
+		if t.Minute() == 59 {
+			goto WRAP
+		}
+	}
+
+	for 1<<uint(t.Second())&s.Second == 0 {
+		t = t.Add(-1 * time.Second)
+
+		if t.Second() == 59 {
+			goto WRAP
+		}
+	}
+
+	return t.In(origLocation)
+}
+
+// hasYears reports whether the schedule accepts only some years.
+func (s *SpecSchedule) hasYears() bool {
+	return s.Year != [3]uint64{}
+}
+
+// acceptsYear reports whether the schedule accepts the given year.
+func (s *SpecSchedule) acceptsYear(year int) bool {
+	if !s.hasYears() {
+		return true
+	}
+	if year < int(years.min) || year > int(years.max) {
+		return false
+	}
+	n := year - int(years.min)
+	return s.Year[n/64]&(1<<(n%64)) > 0
+}
+
+// yearValues returns the years accepted by the schedule in ascending order.
+func (s *SpecSchedule) yearValues() []int {
+	var values []int
+	for year := int(years.min); year <= int(years.max); year++ {
+		if s.acceptsYear(year) {
+			values = append(values, year)
+		}
+	}
+	return values
+}
+
+// prevYear returns the last year accepted by the schedule up to the given
+// year, if any.
+func (s *SpecSchedule) prevYear(year int) (int, bool) {
+	if !s.hasYears() {
+		return year, true
+	}
+	for year = min(year, int(years.max)); year >= int(years.min); year-- {
+		if s.acceptsYear(year) {
+			return year, true
+		}
+	}
+	return 0, false
+}
+
+// nextYear returns the first year accepted by the schedule from the given
+// year, if any.
+func (s *SpecSchedule) nextYear(year int) (int, bool) {
+	if !s.hasYears() {
+		return year, true
+	}
+	for year = max(year, int(years.min)); year <= int(years.max); year++ {
+		if s.acceptsYear(year) {
+			return year, true
+		}
+	}
+	return 0, false
+}
+
 // dayMatches returns true if the schedule's day-of-week and day-of-month
 // restrictions are satisfied by the given time.
 func dayMatches(s *SpecSchedule, t time.Time) bool {
 	var (
-		domMatch = 1<<uint(t.Day())&s.Dom > 0
-		dowMatch = 1<<uint(t.Weekday())&s.Dow > 0
+		domMatch = 1<<uint(t.Day())&s.Dom > 0 || s.LastDom|s.WeekdayDom > 0 && relativeDomMatches(s, t)
+		dowMatch = 1<<uint(t.Weekday())&s.Dow > 0 || s.LastDow|s.NthDow > 0 && relativeDowMatches(s, t)
 	)
 	if s.Dom&starBit > 0 || s.Dow&starBit > 0 {
 		return domMatch && dowMatch
 	}
 	return domMatch || dowMatch
 }
+
+// relativeDomMatches returns true if the given time is one of the days of the
+// month of the schedule relative to the month.
+func relativeDomMatches(s *SpecSchedule, t time.Time) bool {
+	day, last := t.Day(), daysIn(t)
+	if n := last - day; 1<<uint(n)&s.LastDom > 0 {
+		return true
+	}
+	if !isWeekday(t.Weekday()) {
+		return false
+	}
+	if s.WeekdayDom&1 > 0 && day == nearestWeekday(t, last, last) {
+		return true
+	}
+	for n := max(day-2, 1); n <= min(day+2, last); n++ {
+		if 1<<uint(n)&s.WeekdayDom > 0 && day == nearestWeekday(t, n, last) {
+			return true
+		}
+	}
+	return false
+}
+
+// relativeDowMatches returns true if the given time is one of the days of the
+// week of the schedule relative to the month.
+func relativeDowMatches(s *SpecSchedule, t time.Time) bool {
+	weekday := uint(t.Weekday())
+	if 1<<weekday&s.LastDow > 0 && t.Day()+7 > daysIn(t) {
+		return true
+	}
+	k := uint(t.Day()-1) / 7
+	return 1<<(7*k+weekday)&s.NthDow > 0
+}
+
+// nearestWeekday returns the weekday nearest to the given day of the month of
+// t, without leaving the month, which has the given number of days.
+func nearestWeekday(t time.Time, day, last int) int {
+	switch time.Date(t.Year(), t.Month(), day, 12, 0, 0, 0, time.UTC).Weekday() {
+	case time.Saturday:
+		if day == 1 {
+			return day + 2
+		}
+		return day - 1
+	case time.Sunday:
+		if day == last {
+			return day - 2
+		}
+		return day + 1
+	default:
+		return day
+	}
+}
+
+// isWeekday returns true for the days from Monday to Friday.
+func isWeekday(d time.Weekday) bool {
+	return d != time.Saturday && d != time.Sunday
+}
+
+// daysIn returns the number of days of the month of t.
+func daysIn(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 12, 0, 0, 0, time.UTC).Day()
+}
+
+// Spec returns the canonical expression of the schedule: its six fields from
+// seconds to day of week, followed by the year if it has one, and preceded by
+// CRON_TZ= if it has a time zone. The values of each field are collapsed into
+// steps and ranges, e.g. "0 */15 9-17 * * 1-5".
+//
+// The expression can be parsed back by a parser with the Second, Minute, Hour,
+// Dom, Month, Dow, Quartz and YearOptional options.
+func (s *SpecSchedule) Spec() string {
+	fields := []string{
+		formatField(s.Second, seconds, true),
+		formatField(s.Minute, minutes, true),
+		formatField(s.Hour, hours, true),
+		formatField(s.Dom, dom, false, s.domModifiers()...),
+		formatField(s.Month, months, true),
+		formatField(s.Dow, dow, false, s.dowModifiers()...),
+	}
+	if s.hasYears() {
+		fields = append(fields, formatValues(s.yearValues(), years))
+	}
+
+	spec := strings.Join(fields, " ")
+	if s.Location != nil && s.Location != time.Local {
+		spec = "CRON_TZ=" + s.Location.String() + " " + spec
+	}
+	return spec
+}
+
+// String returns the canonical expression of the schedule, see Spec.
+func (s *SpecSchedule) String() string {
+	return s.Spec()
+}
+
+// domModifiers returns the Quartz expressions of the day of month field.
+func (s *SpecSchedule) domModifiers() []string {
+	var exprs []string
+	for n := 0; n < 31; n++ {
+		switch {
+		case s.LastDom&(1<<n) == 0:
+		case n == 0:
+			exprs = append(exprs, "L")
+		default:
+			exprs = append(exprs, "L-"+strconv.Itoa(n))
+		}
+	}
+	for n := 0; n <= 31; n++ {
+		switch {
+		case s.WeekdayDom&(1<<n) == 0:
+		case n == 0:
+			exprs = append(exprs, "LW")
+		default:
+			exprs = append(exprs, strconv.Itoa(n)+"W")
+		}
+	}
+	return exprs
+}
+
+// dowModifiers returns the Quartz expressions of the day of week field.
+func (s *SpecSchedule) dowModifiers() []string {
+	var exprs []string
+	for n := 0; n < 7; n++ {
+		if s.LastDow&(1<<n) > 0 {
+			exprs = append(exprs, strconv.Itoa(n)+"L")
+		}
+	}
+	for k := 1; k <= 5; k++ {
+		for n := 0; n < 7; n++ {
+			if s.NthDow&(1<<(7*(k-1)+n)) > 0 {
+				exprs = append(exprs, strconv.Itoa(n)+"#"+strconv.Itoa(k))
+			}
+		}
+	}
+	return exprs
+}
+
+// formatField returns the expression of a field from its bit set and Quartz
+// expressions. All the values are written as a star if the star bit is set, or
+// if anyStar is true, since only the day fields depend on the star bit.
+func formatField(bits uint64, r bounds, anyStar bool, modifiers ...string) string {
+	values := setValues(bits, r)
+	var exprs []string
+	switch {
+	case len(values) == int(r.max-r.min+1) && (anyStar || bits&starBit > 0):
+		exprs = append(exprs, "*")
+	case len(values) > 0:
+		exprs = append(exprs, formatValues(values, r))
+	}
+	return strings.Join(append(exprs, modifiers...), ",")
+}
+
+// formatValues returns a short expression of the given ascending values,
+// collapsing the runs of at least three evenly spaced values into steps, e.g.
+// "*/15", "5/10" or "1-9/2", and ranges. The items stay in ascending order.
+func formatValues(values []int, r bounds) string {
+	var exprs []string
+	for i := 0; i < len(values); {
+		start, step := values[i], 0
+		if i+1 < len(values) {
+			step = values[i+1] - start
+		}
+		j := i
+		for j+1 < len(values) && values[j+1]-values[j] == step {
+			j++
+		}
+		if j-i < 2 {
+			exprs = append(exprs, strconv.Itoa(start))
+			i++
+			continue
+		}
+
+		last := values[j]
+		switch {
+		case step == 1:
+			exprs = append(exprs, fmt.Sprintf("%d-%d", start, last))
+		case last+step <= int(r.max):
+			exprs = append(exprs, fmt.Sprintf("%d-%d/%d", start, last, step))
+		case start == int(r.min):
+			exprs = append(exprs, fmt.Sprintf("*/%d", step))
+		default:
+			exprs = append(exprs, fmt.Sprintf("%d/%d", start, step))
+		}
+		i = j + 1
+	}
+	return strings.Join(exprs, ",")
+}
@@ -298,3 +298,242 @@ func TestSlash0NoHang(t *testing.T) {
 		t.Error("expected an error on 0 increment")
 	}
 }
+
+func TestNext_Quartz(t *testing.T) {
+	parser := NewParser(Minute | Hour | Dom | Month | Dow | Descriptor | Quartz)
+	runs := []struct {
+		time, spec string
+		expected   string
+	}{
+		// Last days of the month.
+		{"Sat Feb 10 00:00 2024", "0 0 L * *", "Thu Feb 29 00:00 2024"},
+		{"Sat Feb 10 00:00 2024", "0 0 L-2 * *", "Tue Feb 27 00:00 2024"},
+		{"Fri Mar 1 00:00 2024", "0 0 L 2 *", "Fri Feb 28 00:00 2025"},
+		{"Fri Feb 2 00:00 2024", "0 0 1,L * *", "Thu Feb 29 00:00 2024"},
+
+		// Nearest weekdays, without leaving the month.
+		{"Sat Mar 2 00:00 2024", "0 0 LW * *", "Fri Mar 29 00:00 2024"},
+		{"Wed May 15 00:00 2024", "0 0 1W * *", "Mon Jun 3 00:00 2024"},
+		{"Sat Jun 1 00:00 2024", "0 0 15W * *", "Fri Jun 14 00:00 2024"},
+		{"Sat Jun 1 00:00 2024", "0 0 30W * *", "Fri Jun 28 00:00 2024"},
+		{"Sat Jun 1 00:00 2024", "0 0 3W * *", "Mon Jun 3 00:00 2024"},
+
+		// Last and nth days of the week.
+		{"Fri Mar 1 00:00 2024", "0 0 * * 5L", "Fri Mar 29 00:00 2024"},
+		{"Fri Mar 1 00:00 2024", "0 0 * * fri#2", "Fri Mar 8 00:00 2024"},
+		{"Fri Mar 1 00:00 2024", "0 0 * * 1#5", "Mon Apr 29 00:00 2024"},
+
+		// If both are restricted, then only one needs to match.
+		{"Sat Feb 10 00:00 2024", "0 0 L * 1#1", "Thu Feb 29 00:00 2024"},
+		{"Sat Feb 10 00:00 2024", "0 0 L * 1,6", "Mon Feb 12 00:00 2024"},
+	}
+
+	for _, c := range runs {
+		sched, err := parser.Parse(c.spec)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		actual := sched.Next(getTime(c.time))
+		expected := getTime(c.expected)
+		if !actual.Equal(expected) {
+			t.Errorf("%s, \"%s\": (expected) %v != %v (actual)", c.time, c.spec, expected, actual)
+		}
+	}
+}
+
+func TestNext_Year(t *testing.T) {
+	parser := NewParser(Minute | Hour | Dom | Month | Dow | YearOptional)
+	runs := []struct {
+		time, spec string
+		expected   string
+	}{
+		{"Mon Jul 9 14:45 2012", "0 0 * * *", "Tue Jul 10 00:00 2012"},
+		{"Mon Jul 9 14:45 2012", "0 0 * * * *", "Tue Jul 10 00:00 2012"},
+		{"Mon Jul 9 14:45 2012", "0 0 * * * 2012", "Tue Jul 10 00:00 2012"},
+		{"Mon Jul 9 14:45 2012", "0 0 1 1 * 2027", "Fri Jan 1 00:00 2027"},
+		{"Mon Jul 9 14:45 2012", "0 0 1 1 * 2010-2011", ""},
+		{"Fri Jan 1 00:00 2027", "0 0 1 1 * 2027", ""},
+		{"Mon Jul 9 14:45 2012", "0 0 1 7 * 2012,2015/5", "Wed Jul 1 00:00 2015"},
+		{"Wed Jul 1 00:00 2015", "0 0 1 7 * 2012,2015/5", "Sat Jul 1 00:00 2020"},
+		{"Sun Jul 1 00:00 2096", "0 0 1 1 * 2098,2099", "Wed Jan 1 00:00 2098"},
+		{"Wed Jan 1 00:00 2098", "0 0 1 1 * 2098,2099", "Thu Jan 1 00:00 2099"},
+
+		// Leap days beyond five years from now, but within the accepted years.
+		{"Mon Jul 9 14:45 2012", "0 0 29 2 * 2030-2040", "Fri Feb 29 00:00 2032"},
+
+		// Sparse years are searched up to the last one.
+		{"Mon Jul 9 14:45 2012", "0 0 29 2 * 2030,2040", "Wed Feb 29 00:00 2040"},
+		{"Mon Jul 9 14:45 2012", "0 0 29 2 * 2029,2031,2037,2041,2044", "Mon Feb 29 00:00 2044"},
+
+		// Impossible days are given up after the last accepted year.
+		{"Mon Jul 9 14:45 2012", "0 0 30 2 * 2030-2099", ""},
+		{"Mon Jul 9 14:45 2012", "0 0 29 2 * 2029-2031", ""},
+	}
+
+	for _, c := range runs {
+		sched, err := parser.Parse(c.spec)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		actual := sched.Next(getTime(c.time))
+		expected := getTime(c.expected)
+		if !actual.Equal(expected) {
+			t.Errorf("%s, \"%s\": (expected) %v != %v (actual)", c.time, c.spec, expected, actual)
+		}
+	}
+
+	// Schedules stay comparable.
+	a, _ := parser.Parse("0 0 1 1 * 2030-2040")
+	b, _ := parser.Parse("0 0 1 1 * 2030-2040")
+	if *a.(*SpecSchedule) != *b.(*SpecSchedule) {
+		t.Error("expected equal schedules")
+	}
+
+	for _, spec := range []string{"0 0 * * * 1969", "0 0 * * * 2100", "0 0 * * * 2030-2020", "0 0 * * * x"} {
+		if _, err := parser.Parse(spec); err == nil {
+			t.Error("expected an error parsing: ", spec)
+		}
+	}
+}
+
+func TestSpecSchedule_Spec(t *testing.T) {
+	parser := NewParser(SecondOptional | Minute | Hour | Dom | Month | Dow | Descriptor | Quartz)
+	full := NewParser(Second | Minute | Hour | Dom | Month | Dow | Quartz | YearOptional)
+	runs := []struct {
+		spec, expected string
+	}{
+		{"*/15 9-17 * * 1-5", "0 */15 9-17 * * 1-5"},
+		{"0/15 9,10,11,12 ? * MON-FRI", "0 */15 9-12 * * 1-5"},
+		{"5/10 * * * *", "0 5/10 * * * *"},
+		{"0-20/5 * * * *", "0 0-20/5 * * * *"},
+		{"0,30 0-23 1-31 1-12 *", "0 0,30 * 1-31 * *"},
+		{"1,2,3,5,7,8 * * * * *", "1-3,5,7,8 * * * * *"},
+		{"1,2,3,5,8,13,21,34,55 * * * *", "0 1-3,5,8,13,21,34,55 * * * *"},
+		{"1,2,4,6,8 * * * *", "0 1,2-8/2 * * * *"},
+		{"0 0 */2 * 0-6", "0 0 0 */2 * 0-6"},
+		{"0 0 L-2,L,15W,LW * *", "0 0 0 L,L-2,LW,15W * *"},
+		{"0 0 1,L * FRIL,1#2", "0 0 0 1,L * 5L,1#2"},
+		{"@weekly", "0 0 0 * * 0"},
+		{"CRON_TZ=America/New_York 30 9 * * *", "CRON_TZ=America/New_York 0 30 9 * * *"},
+		{"TZ=UTC @daily", "CRON_TZ=UTC 0 0 0 * * *"},
+	}
+
+	for _, c := range runs {
+		sched, err := parser.Parse(c.spec)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		actual := sched.(*SpecSchedule).Spec()
+		if actual != c.expected {
+			t.Errorf("%s: (expected) %s != %s (actual)", c.spec, c.expected, actual)
+		}
+
+		reparsed, err := full.Parse(actual)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if spec := reparsed.(*SpecSchedule).String(); spec != actual {
+			t.Errorf("%s: (expected) %s != %s (reparsed)", c.spec, actual, spec)
+		}
+	}
+
+	sched, err := full.Parse("0 0 0 1 1 * 2026-2028,2030/10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec := sched.(*SpecSchedule).Spec(); spec != "0 0 0 1 1 * 2026-2028,2030/10" {
+		t.Errorf("unexpected spec with years: %s", spec)
+	}
+}
+
+func TestPrev(t *testing.T) {
+	runs := []struct {
+		time, spec string
+		expected   string
+	}{
+		// Simple cases
+		{"Mon Jul 9 15:00 2012", "0 0/15 * * * *", "Mon Jul 9 14:45 2012"},
+		{"Mon Jul 9 15:00:01 2012", "0 0/15 * * * *", "Mon Jul 9 15:00 2012"},
+		{"Mon Jul 9 15:14:59 2012", "0 0/15 * * * *", "Mon Jul 9 15:00 2012"},
+
+		// Wrap around hours and days
+		{"Mon Jul 9 16:20 2012", "0 20-35/15 * * * *", "Mon Jul 9 15:35 2012"},
+		{"Tue Jul 10 00:00 2012", "0 */15 * * * *", "Mon Jul 9 23:45 2012"},
+		{"Tue Jul 10 00:20:15 2012", "15/35 20-35/15 * * * *", "Mon Jul 9 23:35:50 2012"},
+		{"Tue Jul 10 10:20:15 2012", "15/35 20-35/15 10-12 * * *", "Mon Jul 9 12:35:50 2012"},
+
+		// Wrap around months and years
+		{"Thu Aug 9 00:00 2012", "0 0 0 9 Apr-Oct ?", "Mon Jul 9 00:00 2012"},
+		{"Mon Feb 4 00:00 2013", "0 0 0 * Feb Mon", "Mon Feb 27 00:00 2012"},
+		{"Tue Jan 1 00:00:00 2013", "0 * * * * *", "Mon Dec 31 23:59:00 2012"},
+
+		// Leap year
+		{"Mon Feb 29 00:00 2016", "0 0 0 29 Feb ?", "Wed Feb 29 00:00 2012"},
+
+		// Daylight savings time 2am EST (-5) -> 3am EDT (-4)
+		{"2012-03-11T03:00:00-0400", "TZ=America/New_York 0 0 * * * ?", "2012-03-11T01:00:00-0500"},
+		{"2012-03-11T01:00:00-0500", "TZ=America/New_York 0 0 * * * ?", "2012-03-11T00:00:00-0500"},
+		{"2012-03-12T02:00:00-0400", "TZ=America/New_York 0 0 2 * * ?", "2012-03-10T02:00:00-0500"},
+
+		// Daylight savings time 2am EDT (-4) => 1am EST (-5)
+		{"2012-11-04T02:00:00-0500", "TZ=America/New_York 0 0 * * * ?", "2012-11-04T01:00:00-0500"},
+		{"2012-11-04T01:00:00-0500", "TZ=America/New_York 0 0 * * * ?", "2012-11-04T01:00:00-0400"},
+		{"2012-11-05T01:00:00-0500", "TZ=America/New_York 0 0 1 * * ?", "2012-11-04T01:00:00-0500"},
+		{"2012-11-04T01:00:00-0500", "TZ=America/New_York 0 0 1 * * ?", "2012-11-04T01:00:00-0400"},
+
+		// DST resulting in midnight not being a valid time.
+		{"2018-11-05T00:00:00-0200", "TZ=America/Sao_Paulo 0 30 23 * * ?", "2018-11-04T23:30:00-0200"},
+		{"2018-11-04T01:00:00-0200", "TZ=America/Sao_Paulo 0 30 23 * * ?", "2018-11-03T23:30:00-0300"},
+
+		// Unsatisfiable
+		{"Mon Jul 9 23:35 2012", "0 0 0 30 Feb ?", ""},
+	}
+
+	for _, c := range runs {
+		sched, err := secondParser.Parse(c.spec)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		actual := sched.(PrevSchedule).Prev(getTime(c.time))
+		expected := getTime(c.expected)
+		if !actual.Equal(expected) {
+			t.Errorf("%s, \"%s\": (expected) %v != %v (actual)", c.time, c.spec, expected, actual)
+		}
+	}
+}
+
+func TestPrev_Year(t *testing.T) {
+	parser := NewParser(Minute | Hour | Dom | Month | Dow | YearOptional | Quartz)
+	runs := []struct {
+		time, spec string
+		expected   string
+	}{
+		{"Mon Jul 9 14:45 2012", "0 0 1 1 * 2005", "Sat Jan 1 00:00 2005"},
+		{"Mon Jul 9 14:45 2012", "0 0 1 1 * 2013-2020", ""},
+		{"Mon Jul 9 14:45 2012", "0 0 29 2 * 1990-2002", "Tue Feb 29 00:00 2000"},
+
+		// Sparse years are searched down to the first one.
+		{"Mon Jul 9 14:45 2045", "0 0 29 2 * 2028,2039", "Tue Feb 29 00:00 2028"},
+		{"Mon Jul 9 14:45 2045", "0 0 30 2 * 1970-2039", ""},
+		{"Mon Jul 9 14:45 2012", "0 0 L * *", "Sat Jun 30 00:00 2012"},
+		{"Mon Jul 9 14:45 2012", "0 0 * * 1#1", "Mon Jul 2 00:00 2012"},
+	}
+
+	for _, c := range runs {
+		sched, err := parser.Parse(c.spec)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		actual := sched.(PrevSchedule).Prev(getTime(c.time))
+		expected := getTime(c.expected)
+		if !actual.Equal(expected) {
+			t.Errorf("%s, \"%s\": (expected) %v != %v (actual)", c.time, c.spec, expected, actual)
+		}
+	}
+}
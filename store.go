@@ -0,0 +1,107 @@
+package cron
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// entryStore holds the entries of a Cron in a min-heap ordered by their next
+// activation time, with zero times last, and indexed by id.
+//
+// Adding, removing and rescheduling an entry are O(log n), looking it up is
+// O(1) and the next entry to run is always the first one.
+type entryStore struct {
+	entries []*Entry
+	index   map[EntryID]int
+}
+
+func newEntryStore() *entryStore {
+	return &entryStore{index: make(map[EntryID]int)}
+}
+
+// Len, Less, Swap, Push and Pop implement heap.Interface; use the other
+// methods instead.
+
+func (s *entryStore) Len() int { return len(s.entries) }
+
+func (s *entryStore) Less(i, j int) bool { return earlier(s.entries[i], s.entries[j]) }
+
+func (s *entryStore) Swap(i, j int) {
+	s.entries[i], s.entries[j] = s.entries[j], s.entries[i]
+	s.index[s.entries[i].id] = i
+	s.index[s.entries[j].id] = j
+}
+
+func (s *entryStore) Push(x any) {
+	e := x.(*Entry)
+	s.index[e.id] = len(s.entries)
+	s.entries = append(s.entries, e)
+}
+
+func (s *entryStore) Pop() any {
+	n := len(s.entries) - 1
+	e := s.entries[n]
+	s.entries[n] = nil
+	s.entries = s.entries[:n]
+	delete(s.index, e.id)
+	return e
+}
+
+// add adds the entry to the store.
+func (s *entryStore) add(e *Entry) {
+	heap.Push(s, e)
+}
+
+// get returns the entry with the given id, or nil if it couldn't be found.
+func (s *entryStore) get(id EntryID) *Entry {
+	if i, ok := s.index[id]; ok {
+		return s.entries[i]
+	}
+	return nil
+}
+
+// remove removes the entry with the given id, reporting whether it was found.
+func (s *entryStore) remove(id EntryID) bool {
+	i, ok := s.index[id]
+	if ok {
+		heap.Remove(s, i)
+	}
+	return ok
+}
+
+// fix restores the order of the store after the next activation time of the
+// given entry has changed.
+func (s *entryStore) fix(e *Entry) {
+	if i, ok := s.index[e.id]; ok {
+		heap.Fix(s, i)
+	}
+}
+
+// first returns the entry with the earliest next activation time, or nil if
+// the store is empty.
+func (s *entryStore) first() *Entry {
+	if len(s.entries) == 0 {
+		return nil
+	}
+	return s.entries[0]
+}
+
+// sorted returns the entries ordered by their next activation time.
+func (s *entryStore) sorted() []*Entry {
+	entries := append([]*Entry(nil), s.entries...)
+	sort.Slice(entries, func(i, j int) bool { return earlier(entries[i], entries[j]) })
+	return entries
+}
+
+// earlier reports whether a is to be run before b: zero times are "greater"
+// than any other time, and entries with the same time are ordered by id.
+func earlier(a, b *Entry) bool {
+	switch {
+	case a.next.IsZero() != b.next.IsZero():
+		return b.next.IsZero()
+	case !a.next.Equal(b.next):
+		return a.next.Before(b.next)
+	default:
+		return a.id < b.id
+	}
+}
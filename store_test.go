@@ -0,0 +1,51 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntryStore(t *testing.T) {
+	var (
+		store = newEntryStore()
+		base  = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		next  = []time.Duration{3 * time.Hour, 0, time.Hour, 2 * time.Hour, time.Hour}
+	)
+	assert.Nil(t, store.first())
+
+	for i, d := range next {
+		e := NewEntry(EntryID(i+1), Every(time.Hour), nil)
+		if d != 0 {
+			e.next = base.Add(d)
+		}
+		store.add(e)
+	}
+
+	ids := func() []EntryID {
+		var ids []EntryID
+		for _, e := range store.sorted() {
+			ids = append(ids, e.ID())
+		}
+		return ids
+	}
+	assert.Equal(t, []EntryID{3, 5, 4, 1, 2}, ids())
+	assert.Equal(t, EntryID(3), store.first().ID())
+	assert.Equal(t, EntryID(4), store.get(4).ID())
+	assert.Nil(t, store.get(6))
+
+	e := store.get(3)
+	e.next = base.Add(4 * time.Hour)
+	store.fix(e)
+	assert.Equal(t, []EntryID{5, 4, 1, 3, 2}, ids())
+
+	assert.True(t, store.remove(5))
+	assert.False(t, store.remove(5))
+	assert.Nil(t, store.get(5))
+	assert.Equal(t, EntryID(4), store.first().ID())
+	assert.Equal(t, []EntryID{4, 1, 3, 2}, ids())
+	for _, e := range store.entries {
+		assert.Equal(t, e, store.get(e.ID()))
+	}
+}